@@ -0,0 +1,117 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Fingerprint identifies the origin of the error: the context and the caller
+// of the first fault in the chain. The message is used for other errors.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	fingerprint := err.Error()
+	walk(err, func(err error) bool {
+		if e, ok := err.(*errType); ok {
+			fingerprint = e.head.Error()
+			if name, line := e.location(); name != "" {
+				fingerprint += " @ " + name + ":" + strconv.Itoa(line)
+			}
+			return false
+		}
+		return true
+	})
+
+	return fingerprint
+}
+
+// Aggregator accumulates errors over the interval and flushes counts of
+// errors grouped by fingerprint. Use it inside batch consumers to emit
+// one summary log line per interval rather than one per failure.
+//
+//	agg := faults.NewAggregator(time.Minute, func(counts map[string]int) {
+//		slog.Warn("failures", "counts", counts)
+//	})
+//	defer agg.Close()
+type Aggregator struct {
+	mu     sync.Mutex
+	counts map[string]int
+	flush  func(map[string]int)
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+	close  sync.Once
+}
+
+// NewAggregator creates aggregator that flushes counts every interval.
+func NewAggregator(interval time.Duration, flush func(map[string]int)) *Aggregator {
+	a := &Aggregator{
+		counts: map[string]int{},
+		flush:  flush,
+		ticker: time.NewTicker(interval),
+		done:   make(chan struct{}),
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		for {
+			select {
+			case <-a.ticker.C:
+				a.Flush()
+			case <-a.done:
+				return
+			}
+		}
+	}()
+
+	return a
+}
+
+// Add the error to the aggregator, nil errors are ignored.
+func (a *Aggregator) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	fingerprint := Fingerprint(err)
+
+	a.mu.Lock()
+	a.counts[fingerprint]++
+	a.mu.Unlock()
+}
+
+// Flush counts accumulated so far. The callback is not invoked if there
+// are no errors.
+func (a *Aggregator) Flush() {
+	a.mu.Lock()
+	counts := a.counts
+	a.counts = map[string]int{}
+	a.mu.Unlock()
+
+	if len(counts) > 0 {
+		a.flush(counts)
+	}
+}
+
+// Close stops the aggregator and flushes remaining counts, consequent calls
+// are no-op.
+func (a *Aggregator) Close() {
+	a.close.Do(func() {
+		a.ticker.Stop()
+		close(a.done)
+		a.wg.Wait()
+		a.Flush()
+	})
+}
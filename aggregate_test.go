@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestFingerprint(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Fast("b %d")
+	)
+
+	for e, expect := range map[error]string{
+		errA.With(err, 1):                      "a %d @ github.com/fogfish/faults_test.TestFingerprint:27",
+		errB.With(err, 1):                      "b %d",
+		fmt.Errorf("c: %w", errB.With(err, 2)): "b %d",
+		err:                                    "just error",
+	} {
+		if !withCallers {
+			expect, _, _ = strings.Cut(expect, " @ ")
+		}
+
+		if v := errors.Fingerprint(e); v != expect {
+			t.Errorf("failed: %s", v)
+		}
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	const errA = errors.Fast("a %d")
+
+	flushed := make(chan map[string]int, 10)
+	agg := errors.NewAggregator(time.Hour, func(counts map[string]int) { flushed <- counts })
+
+	agg.Add(errA.With(err, 1))
+	agg.Add(errA.With(err, 2))
+	agg.Add(err)
+	agg.Add(nil)
+	agg.Flush()
+	agg.Flush()
+
+	counts := <-flushed
+	if len(counts) != 2 || counts["a %d"] != 2 || counts["just error"] != 1 {
+		t.Errorf("failed: %v", counts)
+	}
+
+	agg.Add(err)
+	agg.Close()
+	agg.Close()
+
+	counts = <-flushed
+	if len(counts) != 1 || counts["just error"] != 1 {
+		t.Errorf("failed: %v", counts)
+	}
+
+	if len(flushed) != 0 {
+		t.Errorf("failed: empty flush")
+	}
+}
+
+func TestAggregatorTick(t *testing.T) {
+	flushed := make(chan map[string]int, 10)
+	agg := errors.NewAggregator(time.Millisecond, func(counts map[string]int) { flushed <- counts })
+	defer agg.Close()
+
+	agg.Add(err)
+
+	select {
+	case counts := <-flushed:
+		if counts["just error"] != 1 {
+			t.Errorf("failed: %v", counts)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("failed: no flush")
+	}
+}
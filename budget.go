@@ -0,0 +1,143 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// BudgetEntry is the number of faults with the fingerprint, see Fingerprint
+type BudgetEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// Budget counts faults of the single request, so that access logs highlight
+// requests that have churned through many errors before succeeding. The budget
+// is carried by the context.Context of the request, see Budgeted.
+//
+// Counting is explicit: only errors passed to Track (or Add) are counted.
+// Contexts create faults without the request context, faults created while
+// serving the request are not counted automatically.
+type Budget struct {
+	mu     sync.Mutex
+	counts map[string]int
+	count  int
+}
+
+// budgetTop is number of fingerprints logged by the budget
+const budgetTop = 3
+
+type budgetKey struct{}
+
+// WithBudget returns the context carrying the new budget.
+func WithBudget(ctx context.Context) (context.Context, *Budget) {
+	b := &Budget{counts: map[string]int{}}
+	return context.WithValue(ctx, budgetKey{}, b), b
+}
+
+// BudgetOf returns the budget carried by the context, nil if there is none.
+func BudgetOf(ctx context.Context) *Budget {
+	b, _ := ctx.Value(budgetKey{}).(*Budget)
+	return b
+}
+
+// Track adds the error to the budget of the context and returns the error
+// as is. Errors are not tracked if the context carries no budget.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.Track(ctx, errGet.With(err, key))
+//	}
+func Track(ctx context.Context, err error) error {
+	BudgetOf(ctx).Add(err)
+	return err
+}
+
+// Add the error to the budget, nil errors are ignored.
+func (b *Budget) Add(err error) {
+	if b == nil || err == nil {
+		return
+	}
+
+	fingerprint := Fingerprint(err)
+
+	b.mu.Lock()
+	b.count++
+	b.counts[fingerprint]++
+	b.mu.Unlock()
+}
+
+// Count returns the number of faults of the request.
+func (b *Budget) Count() int {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.count
+}
+
+// Top returns n most frequent fingerprints of the request.
+func (b *Budget) Top(n int) []BudgetEntry {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	seq := make([]BudgetEntry, 0, len(b.counts))
+	for fingerprint, count := range b.counts {
+		seq = append(seq, BudgetEntry{Fingerprint: fingerprint, Count: count})
+	}
+	b.mu.Unlock()
+
+	slices.SortFunc(seq, func(a, b BudgetEntry) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Fingerprint, b.Fingerprint)
+	})
+
+	if len(seq) > n {
+		seq = seq[:n]
+	}
+
+	return seq
+}
+
+// LogValue renders the count and top fingerprints of the budget as
+// the group of the log entry.
+func (b *Budget) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("count", b.Count()),
+		slog.Any("top", b.Top(budgetTop)),
+	)
+}
+
+// Budgeted is the http middleware carrying the budget within the context
+// of the request. The access log is invoked once the request is served.
+//
+//	http.Handle("/", faults.Budgeted(api, func(r *http.Request, b *faults.Budget) {
+//		slog.Info("access", "path", r.URL.Path, "faults", b)
+//	}))
+func Budgeted(next http.Handler, log func(*http.Request, *Budget)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, budget := WithBudget(r.Context())
+		r = r.WithContext(ctx)
+
+		defer log(r, budget)
+		next.ServeHTTP(w, r)
+	})
+}
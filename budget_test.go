@@ -0,0 +1,67 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestBudget(t *testing.T) {
+	const (
+		errA = errors.Fast("a")
+		errB = errors.Fast("b")
+	)
+
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			errors.Track(r.Context(), errA.With(err))
+		}
+		errors.Track(r.Context(), errB.With(err))
+		errors.Track(r.Context(), nil)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var (
+		count int
+		top   []errors.BudgetEntry
+		buf   bytes.Buffer
+	)
+
+	handler := errors.Budgeted(api, func(r *http.Request, b *errors.Budget) {
+		count, top = b.Count(), b.Top(1)
+		slog.New(slog.NewJSONHandler(&buf, nil)).Info("access", "faults", b)
+	})
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if count != 4 || fmt.Sprint(top) != "[{a 3}]" {
+		t.Errorf("failed: %d %v", count, top)
+	}
+
+	if log := buf.String(); !strings.Contains(log, `"faults":{"count":4,"top":[{"fingerprint":"a","count":3},{"fingerprint":"b","count":1}]}`) {
+		t.Errorf("failed: %s", log)
+	}
+
+	if e := errors.Track(context.Background(), err); e != err {
+		t.Errorf("failed: track without budget")
+	}
+
+	if b := errors.BudgetOf(context.Background()); b != nil || b.Count() != 0 || b.Top(1) != nil {
+		t.Errorf("failed: nil budget")
+	}
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "runtime/debug"
+
+// BuildInfo identifies the deployment that has produced the fault
+type BuildInfo struct {
+	// Version of the main module
+	Version string
+
+	// Revision of the version control system, suffixed by "-dirty"
+	// if the working tree has been modified
+	Revision string
+}
+
+// Builds enables stamping of faults with the build info of the binary
+// (debug.ReadBuildInfo), so cross-version incident analysis tells which
+// deployment has produced the fault during rolling deployments, see BuildOf.
+//
+//	faults.Configure(faults.Builds(true))
+func Builds(enabled bool) Option {
+	return func(c *config) {
+		c.build = nil
+		if enabled {
+			c.build = readBuild()
+		}
+	}
+}
+
+func readBuild() *BuildInfo {
+	build := &BuildInfo{}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+
+	build.Version = info.Main.Version
+
+	dirty := false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			build.Revision = s.Value
+		case "vcs.modified":
+			dirty = s.Value == "true"
+		}
+	}
+
+	if dirty && build.Revision != "" {
+		build.Revision += "-dirty"
+	}
+
+	return build
+}
+
+// BuildOf returns the build of the outermost stamped fault in the chain,
+// see Builds.
+//
+//	if build, ok := faults.BuildOf(err); ok {
+//		slog.Error("failed", "err", err, "version", build.Version)
+//	}
+func BuildOf(err error) (BuildInfo, bool) {
+	var build *BuildInfo
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(*errType); ok && e.build != nil {
+			build = e.build
+			return false
+		}
+		return true
+	})
+
+	if build == nil {
+		return BuildInfo{}, false
+	}
+
+	return *build, true
+}
@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"bytes"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestBuildOf(t *testing.T) {
+	const errA = errors.Type("a")
+
+	if _, ok := errors.BuildOf(errA.With(err)); ok {
+		t.Errorf("failed: build is stamped by default")
+	}
+
+	errors.Configure(errors.Builds(true))
+	defer errors.Configure(errors.Builds(false))
+
+	e := errA.With(err)
+
+	info, _ := debug.ReadBuildInfo()
+	if build, ok := errors.BuildOf(e); !ok || build.Version != info.Main.Version {
+		t.Errorf("failed: %+v", build)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Error("failed", "err", e)
+	if !strings.Contains(buf.String(), "err.build.version=") {
+		t.Errorf("failed: %s", buf.String())
+	}
+
+	errors.Configure(errors.Builds(false))
+	if _, ok := errors.BuildOf(errA.With(err)); ok {
+		t.Errorf("failed: build is stamped")
+	}
+}
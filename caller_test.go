@@ -0,0 +1,27 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build !faults_nocaller
+
+package faults_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+// withCallers is false if the suite is built with faults_nocaller
+const withCallers = true
+
+// at renders the caller annotation of the fault declared by the test
+func at(fn string, line int) string {
+	return fmt.Sprintf("[github.com/fogfish/faults_test.%s %d] ", fn, line)
+}
+
+// requireCallers skips the test that asserts callers of faults
+func requireCallers(t *testing.T) { t.Helper() }
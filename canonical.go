@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"regexp"
+	"strings"
+)
+
+// volatile data of rendered errors and substitutes masking it
+var canonicalMasks = []struct {
+	re   *regexp.Regexp
+	mask string
+}{
+	// references to occurrences, see FaultRef
+	{regexp.MustCompile(`#[0-9a-f]{6}-[0-9a-f]+\b`), "#<ref>"},
+	// line numbers of callers, see AboutFunction and AboutFileLine
+	{regexp.MustCompile(`(\[[^\s\[\]]+) \d+\]`), "$1 <line>]"},
+	{regexp.MustCompile(`(\.go):\d+\b`), "$1:<line>"},
+	// durations, see time.Duration and Dur
+	{regexp.MustCompile(`-?\b(\d+(\.\d+)?(ns|us|µs|ms|h|m|s))+\b`), "<duration>"},
+}
+
+// Canonical renders the chain, one cause per line along with functions of
+// callers, masking volatile data: line numbers, durations and references to
+// occurrences. The rendering is stable across builds and runs, it is designed
+// for golden files and log-based assertions.
+//
+//	a
+//		github.com/some/pkg.Function
+//	b: timeout after <duration>
+func Canonical(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	canonical(&sb, err)
+
+	text := sb.String()
+	for _, m := range canonicalMasks {
+		text = m.re.ReplaceAllString(text, m.mask)
+	}
+
+	return text
+}
+
+// canonical writes the fault and its causes, foreign errors are terminal
+func canonical(sb *strings.Builder, err error) {
+	e, ok := err.(*errType)
+	if !ok {
+		sb.WriteString(err.Error())
+		return
+	}
+
+	sb.WriteString(e.Message())
+	if name, _ := e.location(); name != "" {
+		sb.WriteString("\n\t")
+		sb.WriteString(name)
+	}
+
+	causes := []error{e.tail}
+	if list, ok := e.tail.(*errList); ok {
+		causes = list.errs
+	}
+
+	for _, cause := range causes {
+		if cause != nil {
+			sb.WriteString("\n")
+			canonical(sb, cause)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestCanonical(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b after %s")
+		errC = errors.Deep("c %s")
+	)
+
+	ref := errA.With(err)
+
+	for _, tt := range []struct {
+		err    error
+		expect string
+	}{
+		{nil, ""},
+		{err, "just error"},
+		{errA.With(err), "a\n\tgithub.com/fogfish/faults_test.TestCanonical\njust error"},
+		{errB.With(err, 1500*time.Millisecond), "b after <duration>\njust error"},
+		{errB.With(err, errors.Dur(90*time.Second)), "b after <duration>\njust error"},
+		{errC.With(nil, "x"), "c x\n\tgithub.com/fogfish/faults_test.TestCanonical"},
+		{errB.With(err, "2h3m0s"), "b after <duration>\njust error"},
+		{errA.WithAll(err, fmt.Errorf("x: %w", errA.With(err))), "a\n\tgithub.com/fogfish/faults_test.TestCanonical\njust error\nx: [github.com/fogfish/faults_test.TestCanonical <line>] a: just error"},
+		{fmt.Errorf("failed %s at main.go:42", errors.Ref(ref)), "failed #<ref> at main.go:<line>"},
+	} {
+		expect := tt.expect
+		if !withCallers {
+			expect = strings.NewReplacer(
+				"\n\tgithub.com/fogfish/faults_test.TestCanonical", "",
+				"[github.com/fogfish/faults_test.TestCanonical <line>] ", "",
+			).Replace(expect)
+		}
+
+		if text := errors.Canonical(tt.err); text != expect {
+			t.Errorf("failed: %q, expected %q", text, expect)
+		}
+	}
+}
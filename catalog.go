@@ -0,0 +1,145 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// declaration of the error context (Type, Fast, Deep, Coded, SafeN)
+type declaration interface {
+	error
+
+	// number of template arguments, -1 if variadic
+	arity() int
+
+	// renders template with zero values of arguments
+	zero() string
+}
+
+// Catalog is a list of error contexts declared by the package.
+//
+//	var catalog = faults.Catalog{errSomeA, errSomeB}
+type Catalog []error
+
+// SelfTest validates every declaration of the catalog: templates are
+// rendered with zero values, verbs are consistent with arity and type of
+// arguments, templates and codes are unique. Known classes (including custom
+// ones appended to Classes) must have mappings, see LoadMappings.
+// Use it in the unit test of the package.
+//
+//	func TestFaults(t *testing.T) {
+//		catalog.SelfTest(t)
+//	}
+func (c Catalog) SelfTest(t interface {
+	Helper()
+	Errorf(format string, args ...any)
+}) {
+	t.Helper()
+
+	for _, err := range c.check() {
+		t.Errorf("%s", err)
+	}
+}
+
+// matches fmt noise caused by nil values of arguments
+var zeroNil = regexp.MustCompile(`%!.\(([^()=]+=)?<nil>\)`)
+
+func (c Catalog) check() []error {
+	var (
+		errs  []error
+		seen  = map[string]int{}
+		codes = map[string]int{}
+	)
+
+	for i, err := range c {
+		decl, ok := err.(declaration)
+		if !ok {
+			errs = append(errs, fmt.Errorf("#%d %q: not a fault declaration", i, err))
+			continue
+		}
+
+		template := decl.Error()
+		if at, has := seen[template]; has {
+			errs = append(errs, fmt.Errorf("#%d %q: duplicate of #%d", i, template, at))
+		}
+		seen[template] = i
+
+		if c, ok := err.(interface{ ErrCode() string }); ok && c.ErrCode() != "" {
+			if at, has := codes[c.ErrCode()]; has {
+				errs = append(errs, fmt.Errorf("#%d %q: duplicate code %s of #%d", i, template, c.ErrCode(), at))
+			}
+			codes[c.ErrCode()] = i
+		}
+
+		want, ok := verbs(template)
+		if !ok {
+			continue
+		}
+
+		if arity := decl.arity(); arity >= 0 && arity != want {
+			errs = append(errs, fmt.Errorf("#%d %q: template expects %d args, declared %d", i, template, want, arity))
+			continue
+		}
+
+		if msg := zeroNil.ReplaceAllString(decl.zero(), ""); decl.arity() >= 0 && strings.Contains(msg, "%!") {
+			errs = append(errs, fmt.Errorf("#%d %q: template is inconsistent with args: %s", i, template, decl.zero()))
+		}
+	}
+
+	for _, class := range Classes {
+		if !mapped(class) {
+			errs = append(errs, fmt.Errorf("class %q: missing mapping", class))
+		}
+	}
+
+	return errs
+}
+
+// Declares returns true if the error is produced by one of the catalog
+// declarations or matches one of given sentinels (e.g. io.EOF).
+// The absence of error is always declared.
+func (c Catalog) Declares(err error, sentinels ...error) bool {
+	if err == nil {
+		return true
+	}
+
+	for _, decl := range c {
+		if errors.Is(err, decl) {
+			return true
+		}
+	}
+
+	for _, sentinel := range sentinels {
+		if errors.Is(err, sentinel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertDeclared fails the test if the error is not declared by the catalog,
+// enforcing an explicit error contract of functions.
+//
+//	_, err := pkg.Get(ctx, key)
+//	catalog.AssertDeclared(t, err, io.EOF)
+func (c Catalog) AssertDeclared(t interface {
+	Helper()
+	Errorf(format string, args ...any)
+}, err error, sentinels ...error) {
+	t.Helper()
+
+	if !c.Declares(err, sentinels...) {
+		t.Errorf("undeclared error: %s", err)
+	}
+}
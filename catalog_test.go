@@ -0,0 +1,99 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+type tb struct{ errs []string }
+
+func (t *tb) Helper() {}
+func (t *tb) Errorf(format string, args ...any) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func TestCatalogSelfTest(t *testing.T) {
+	catalog := errors.Catalog{
+		errors.Type("a %s"),
+		errors.Fast("b"),
+		errors.Safe1[int]("c %d"),
+		errors.Safe2[string, *int]("d %s %v"),
+		errors.Safe3[string, int, error]("e %s %d %v"),
+		errors.Code("E1", "f"),
+	}
+	catalog.SelfTest(t)
+
+	for _, bad := range []errors.Catalog{
+		{errors.Type("a"), errors.Fast("a")},
+		{errors.Safe1[int]("c %d %d")},
+		{errors.Safe2[int, int]("c %d")},
+		{errors.Safe1[string]("c %d")},
+		{errors.Code("E1", "a"), errors.Code("E1", "b")},
+		{err},
+	} {
+		mock := &tb{}
+		bad.SelfTest(mock)
+		if len(mock.errs) != 1 {
+			t.Errorf("failed: %v", mock.errs)
+		}
+	}
+}
+
+func TestCatalogSelfTestMappings(t *testing.T) {
+	catalog := errors.Catalog{errors.Type("a")}
+
+	defer func(seq []errors.Class) { errors.Classes = seq }(errors.Classes)
+	errors.Classes = append(errors.Classes[:len(errors.Classes):len(errors.Classes)], "self_test")
+
+	mock := &tb{}
+	catalog.SelfTest(mock)
+	if len(mock.errs) != 1 || mock.errs[0] != `class "self_test": missing mapping` {
+		t.Errorf("failed: %v", mock.errs)
+	}
+
+	if err := errors.LoadMappings(strings.NewReader(`{"self_test": {"http": 400}}`)); err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	mock = &tb{}
+	catalog.SelfTest(mock)
+	if len(mock.errs) != 0 {
+		t.Errorf("failed: %v", mock.errs)
+	}
+}
+
+func TestCatalogDeclares(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Safe1[int]("b %d")
+		errC = errors.Fast("c")
+	)
+
+	catalog := errors.Catalog{errA, errB}
+
+	for _, e := range []error{nil, errA.With(err), errB.With(err, 1), fmt.Errorf("x: %w", errA.With(err))} {
+		catalog.AssertDeclared(t, e)
+	}
+
+	catalog.AssertDeclared(t, fmt.Errorf("x: %w", io.EOF), io.EOF)
+
+	for _, e := range []error{err, errC.With(err), io.EOF} {
+		mock := &tb{}
+		catalog.AssertDeclared(mock, e)
+		if len(mock.errs) != 1 {
+			t.Errorf("failed: %v is declared", e)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build go1.23
+
+package faults
+
+import "iter"
+
+// Chain yields every error of the wrap tree depth-first, in the order
+// errors.Is visits them, including contexts of faults.
+//
+//	for e := range faults.Chain(err) {
+//		...
+//	}
+func Chain(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		walk(err, yield)
+	}
+}
@@ -0,0 +1,49 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build go1.23
+
+package faults_test
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestChain(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+	)
+
+	e := errA.With(fmt.Errorf("x: %w", errB.With(err)))
+
+	var seq []string
+	for x := range errors.Chain(e) {
+		seq = append(seq, x.Error())
+	}
+
+	if len(seq) != 6 || seq[1] != "a" || seq[2] != "x: b: just error" || seq[4] != "b" || seq[5] != "just error" {
+		t.Errorf("failed: %q", seq)
+	}
+
+	n := 0
+	for range errors.Chain(e) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("failed: break")
+	}
+
+	for range errors.Chain(nil) {
+		t.Errorf("failed: nil")
+	}
+}
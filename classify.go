@@ -0,0 +1,554 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The constructors annotate the error with the behavior. They return nil
+// if the error is nil so that return values are annotated unconditionally.
+// The annotated error is reachable by errors.As using the concrete type:
+//
+//	var nf faults.NotFoundError
+//	if errors.As(err, &nf) {
+//		nf.Key()
+//	}
+
+// cause of annotated error, safe for zero values
+type cause struct{ err error }
+
+func (e cause) Unwrap() error { return e.err }
+
+// Cause is compatible with github.com/pkg/errors.Cause
+func (e cause) Cause() error { return e.err }
+
+func (e cause) message(kind string) string {
+	if e.err == nil {
+		return kind
+	}
+	return e.err.Error()
+}
+
+// NotFoundError is the error with NotFound behavior
+type NotFoundError struct {
+	cause
+	key string
+}
+
+// ErrNotFound annotates the error with NotFound behavior.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.ErrNotFound(err, key)
+//	}
+func ErrNotFound(err error, key string) error {
+	if err == nil {
+		return nil
+	}
+
+	return NotFoundError{cause: cause{err}, key: key}
+}
+
+func (e NotFoundError) Error() string    { return e.message("not found") }
+func (e NotFoundError) NotFound() string { return e.key }
+func (e NotFoundError) Key() string      { return e.key }
+
+// NotFoundKeyError is the error with NotFound behavior of the typed key
+type NotFoundKeyError[K comparable] struct {
+	cause
+	key K
+}
+
+// ErrNotFoundOf annotates the error with NotFound behavior of the typed key,
+// see IsNotFoundKey. The key is rendered by fmt for IsNotFound.
+//
+//	if err := db.Get(ctx, id); err != nil {
+//		return faults.ErrNotFoundOf(err, id)
+//	}
+func ErrNotFoundOf[K comparable](err error, key K) error {
+	if err == nil {
+		return nil
+	}
+
+	return NotFoundKeyError[K]{cause: cause{err}, key: key}
+}
+
+func (e NotFoundKeyError[K]) Error() string    { return e.message("not found") }
+func (e NotFoundKeyError[K]) NotFound() string { return fmt.Sprint(e.key) }
+func (e NotFoundKeyError[K]) NotFoundKey() K   { return e.key }
+
+// ConflictError is the error with Conflict behavior
+type ConflictError struct {
+	cause
+	key string
+}
+
+// ErrConflict annotates the error with Conflict behavior.
+func ErrConflict(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return ConflictError{cause: cause{err}}
+}
+
+// ErrConflictOn annotates the error with Conflict behavior on the key,
+// see ConflictOf.
+//
+//	if err := db.Put(ctx, key, val); err != nil {
+//		return faults.ErrConflictOn(err, key)
+//	}
+func ErrConflictOn(err error, key string) error {
+	if err == nil {
+		return nil
+	}
+
+	return ConflictError{cause: cause{err}, key: key}
+}
+
+func (e ConflictError) Error() string       { return e.message("conflict") }
+func (e ConflictError) Conflict() bool      { return true }
+func (e ConflictError) ConflictKey() string { return e.key }
+
+// PreConditionFailedError is the error with PreConditionFailed behavior
+type PreConditionFailedError struct{ cause }
+
+// ErrPreConditionFailed annotates the error with PreConditionFailed behavior.
+func ErrPreConditionFailed(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return PreConditionFailedError{cause{err}}
+}
+
+func (e PreConditionFailedError) Error() string            { return e.message("precondition failed") }
+func (e PreConditionFailedError) PreConditionFailed() bool { return true }
+
+// GoneError is the error with Gone behavior
+type GoneError struct{ cause }
+
+// ErrGone annotates the error with Gone behavior.
+func ErrGone(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return GoneError{cause{err}}
+}
+
+func (e GoneError) Error() string { return e.message("gone") }
+func (e GoneError) Gone() bool    { return true }
+
+// TimeoutError is the error with Timeout behavior
+type TimeoutError struct {
+	cause
+	timeout time.Duration
+}
+
+// ErrTimeout annotates the error with Timeout behavior.
+//
+//	ctx, cancel := context.WithTimeout(ctx, timeout)
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.ErrTimeout(err, timeout)
+//	}
+func ErrTimeout(err error, timeout time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return TimeoutError{cause: cause{err}, timeout: timeout}
+}
+
+func (e TimeoutError) Error() string          { return e.message("timeout") }
+func (e TimeoutError) Timeout() time.Duration { return e.timeout }
+
+// NearTimeoutError is the error with NearTimeout behavior
+type NearTimeoutError struct {
+	cause
+	elapsed time.Duration
+}
+
+// ErrNearTimeout annotates the error with NearTimeout behavior, the elapsed
+// time is close to the deadline.
+func ErrNearTimeout(err error, elapsed time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return NearTimeoutError{cause: cause{err}, elapsed: elapsed}
+}
+
+func (e NearTimeoutError) Error() string              { return e.message("near timeout") }
+func (e NearTimeoutError) NearTimeout() time.Duration { return e.elapsed }
+
+// nearTimeoutRatio is the fraction of the deadline considered as near timeout
+const nearTimeoutRatio = 0.8
+
+// elapsedOf classifies the error by the elapsed time: exceeded deadline is
+// Timeout, elapsed time beyond 80% of the deadline is NearTimeout.
+// The error might be nil, behaviors are zero-value safe.
+func elapsedOf(err error, elapsed, deadline time.Duration) error {
+	switch {
+	case elapsed >= deadline:
+		return TimeoutError{cause: cause{err}, timeout: elapsed}
+	case float64(elapsed) >= nearTimeoutRatio*float64(deadline):
+		return NearTimeoutError{cause: cause{err}, elapsed: elapsed}
+	default:
+		return err
+	}
+}
+
+// StatusCodeError is the error with StatusCode behavior
+type StatusCodeError struct {
+	cause
+	code string
+}
+
+// ErrStatusCode annotates the error with StatusCode behavior.
+func ErrStatusCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+
+	return StatusCodeError{cause: cause{err}, code: code}
+}
+
+func (e StatusCodeError) Error() string      { return e.message("status code " + e.code) }
+func (e StatusCodeError) StatusCode() string { return e.code }
+
+// HTTPStatusError is the error with HTTPStatus behavior, it is StatusCode
+// as well for backward compatibility.
+type HTTPStatusError struct {
+	cause
+	status int
+}
+
+// ErrHTTPStatus annotates the error with the numeric status code.
+//
+//	if resp.StatusCode >= 400 {
+//		return faults.ErrHTTPStatus(err, resp.StatusCode)
+//	}
+func ErrHTTPStatus(err error, status int) error {
+	if err == nil {
+		return nil
+	}
+
+	return HTTPStatusError{cause: cause{err}, status: status}
+}
+
+func (e HTTPStatusError) Error() string      { return e.message("status code " + e.StatusCode()) }
+func (e HTTPStatusError) HTTPStatus() int    { return e.status }
+func (e HTTPStatusError) StatusCode() string { return strconv.Itoa(e.status) }
+
+// DegradedError is the error with Degraded behavior
+type DegradedError struct{ cause }
+
+// ErrDegraded annotates the error with Degraded behavior.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.ErrDegraded(err)
+//	}
+func ErrDegraded(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return DegradedError{cause{err}}
+}
+
+func (e DegradedError) Error() string  { return e.message("degraded") }
+func (e DegradedError) Degraded() bool { return true }
+
+// DownError is the error with Down behavior
+type DownError struct{ cause }
+
+// ErrDown annotates the error with Down behavior.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.ErrDown(err)
+//	}
+func ErrDown(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return DownError{cause{err}}
+}
+
+func (e DownError) Error() string { return e.message("down") }
+func (e DownError) Down() bool    { return true }
+
+// Violation is the failure of the field validation
+type Violation struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string { return v.Field + ": " + v.Message }
+
+// ValidationError is the error with Validation behavior
+type ValidationError struct {
+	cause
+	violations []Violation
+}
+
+// ErrValidation annotates the error with Validation behavior, the error might
+// be nil. The error is returned unchanged if there are no violations, so nil
+// stands for the valid input.
+//
+//	var seq []faults.Violation
+//	if req.Name == "" {
+//		seq = append(seq, faults.Violation{Field: "name", Rule: "required", Message: "is required"})
+//	}
+//	return faults.ErrValidation(nil, seq...)
+func ErrValidation(err error, violations ...Violation) error {
+	if len(violations) == 0 {
+		return err
+	}
+
+	return ValidationError{cause: cause{err}, violations: violations}
+}
+
+func (e ValidationError) Error() string {
+	seq := make([]string, len(e.violations))
+	for i, v := range e.violations {
+		seq[i] = v.String()
+	}
+
+	msg := "invalid"
+	if len(seq) > 0 {
+		msg += " " + strings.Join(seq, "; ")
+	}
+	if e.err != nil {
+		msg += ": " + e.err.Error()
+	}
+	return msg
+}
+
+func (e ValidationError) Violations() []Violation { return e.violations }
+
+// RateLimitedError is the error with RateLimited behavior
+type RateLimitedError struct {
+	cause
+	after time.Duration
+}
+
+// ErrRateLimited annotates the error with RateLimited behavior, the after is
+// the backoff advertised by the dependency (e.g. Retry-After header).
+//
+//	if resp.StatusCode == http.StatusTooManyRequests {
+//		return faults.ErrRateLimited(err, after)
+//	}
+func ErrRateLimited(err error, after time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return RateLimitedError{cause: cause{err}, after: after}
+}
+
+func (e RateLimitedError) Error() string             { return e.message("rate limited") }
+func (e RateLimitedError) RetryAfter() time.Duration { return e.after }
+func (e RateLimitedError) RateLimited() bool         { return true }
+
+// RetryAfterError is the error with the backoff hint, see RetryAfter
+type RetryAfterError struct {
+	cause
+	after time.Duration
+}
+
+// ErrRetryAfter annotates the error with the backoff hint given by HTTP
+// Retry-After header, see ParseRetryAfter. The error is returned unchanged
+// if the header is absent or invalid.
+//
+//	if resp.StatusCode == http.StatusServiceUnavailable {
+//		return faults.ErrRetryAfter(errUnavailable.With(nil), resp.Header.Get("Retry-After"))
+//	}
+func ErrRetryAfter(err error, header string) error {
+	if err == nil {
+		return nil
+	}
+
+	after, ok := ParseRetryAfter(header)
+	if !ok {
+		return err
+	}
+
+	return RetryAfterError{cause: cause{err}, after: after}
+}
+
+func (e RetryAfterError) Error() string             { return e.message("retry after") }
+func (e RetryAfterError) RetryAfter() time.Duration { return e.after }
+
+// RetryableError is the error with Retryable behavior
+type RetryableError struct{ cause }
+
+// ErrRetryable annotates the error with Retryable behavior, it marks any
+// fault as retryable when wrapping.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return errSome.With(faults.ErrRetryable(err))
+//	}
+func ErrRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return RetryableError{cause{err}}
+}
+
+func (e RetryableError) Error() string   { return e.message("retryable") }
+func (e RetryableError) Retryable() bool { return true }
+
+// TemporaryError is the error with Temporary behavior
+type TemporaryError struct{ cause }
+
+// ErrTemporary annotates the error with Temporary behavior.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.ErrTemporary(err)
+//	}
+func ErrTemporary(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return TemporaryError{cause{err}}
+}
+
+func (e TemporaryError) Error() string   { return e.message("temporary") }
+func (e TemporaryError) Temporary() bool { return true }
+
+// LeveledError is the error with Severity behavior
+type LeveledError struct {
+	cause
+	level SeverityLevel
+}
+
+// ErrSeverity annotates the error with the severity, see SeverityOf.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.ErrSeverity(err, faults.SeverityWarn)
+//	}
+func ErrSeverity(err error, level SeverityLevel) error {
+	if err == nil {
+		return nil
+	}
+
+	return LeveledError{cause: cause{err}, level: level}
+}
+
+func (e LeveledError) Error() string           { return e.message(string(e.level)) }
+func (e LeveledError) Severity() SeverityLevel { return e.level }
+
+// TransientError is the error either transient or permanent, see IsTransient
+type TransientError struct {
+	cause
+	transient bool
+}
+
+// Transient annotates the error as transient, re-queueing of the message
+// is worthwhile.
+//
+//	if err := db.Put(ctx, msg); err != nil {
+//		return faults.Transient(err)
+//	}
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return TransientError{cause: cause{err}, transient: true}
+}
+
+// Permanent annotates the error as permanent, re-queueing of the message
+// is pointless.
+//
+//	if err := json.Unmarshal(msg, &v); err != nil {
+//		return faults.Permanent(err)
+//	}
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return TransientError{cause: cause{err}, transient: false}
+}
+
+func (e TransientError) Error() string {
+	if e.transient {
+		return e.message("transient")
+	}
+	return e.message("permanent")
+}
+
+func (e TransientError) Transient() bool { return e.transient }
+
+// ClassifiedError is the error with explicitly assigned class
+type ClassifiedError struct {
+	cause
+	class Class
+}
+
+// ErrClass assigns the class to the error, see ClassOf.
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return faults.ErrClass(err, faults.ClassDown)
+//	}
+func ErrClass(err error, class Class) error {
+	if err == nil {
+		return nil
+	}
+
+	return ClassifiedError{cause: cause{err}, class: class}
+}
+
+func (e ClassifiedError) Error() string     { return e.message(string(e.class)) }
+func (e ClassifiedError) FaultClass() Class { return e.class }
+
+// EnsureClassified assigns the fallback class to the unclassified error,
+// classified errors are returned unchanged. It is the guard for the outermost
+// handler so that nothing escapes as an unclassified error.
+//
+//	return faults.EnsureClassified(err, faults.ClassInternal)
+func EnsureClassified(err error, fallback Class) error {
+	if err == nil || ClassOf(err) != "" {
+		return err
+	}
+
+	return ErrClass(err, fallback)
+}
+
+// ExpectedError is the fault expected by the caller, see Expectation
+type ExpectedError struct {
+	cause
+	reason string
+}
+
+// ErrExpected annotates the error as expected for the reason, see IsExpected.
+//
+//	if err := db.Get(ctx, key); faults.IsNotFound(err) {
+//		return faults.ErrExpected(err, "not_found")
+//	}
+func ErrExpected(err error, reason string) error {
+	if err == nil {
+		return nil
+	}
+
+	return ExpectedError{cause: cause{err}, reason: reason}
+}
+
+func (e ExpectedError) Error() string           { return e.message("expected") }
+func (e ExpectedError) Expected() string        { return e.reason }
+func (e ExpectedError) Severity() SeverityLevel { return SeverityDebug }
@@ -0,0 +1,336 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestDegraded(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrDegraded(err))
+	if !errors.IsDegraded(e) || errors.IsDown(e) {
+		t.Errorf("failed: degraded %v", e)
+	}
+
+	if !stderrors.Is(e, err) || errors.ErrDegraded(err).Error() != err.Error() {
+		t.Errorf("failed: degraded changes error %v", e)
+	}
+
+	if errors.ErrDegraded(nil) != nil {
+		t.Errorf("failed: degraded nil")
+	}
+}
+
+func TestDown(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrDown(err))
+	if !errors.IsDown(e) || errors.IsDegraded(e) {
+		t.Errorf("failed: down %v", e)
+	}
+
+	if !stderrors.Is(e, err) {
+		t.Errorf("failed: down changes error %v", e)
+	}
+
+	if !errors.IsRetryable(e) || !errors.Inspect(e).Retryable {
+		t.Errorf("failed: down is retryable by mapping %v", e)
+	}
+
+	if errors.ErrDown(nil) != nil {
+		t.Errorf("failed: down nil")
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrRetryable(err))
+	if !errors.IsRetryable(e) || errors.IsRetryable(errA.With(err)) {
+		t.Errorf("failed: retryable %v", e)
+	}
+
+	if !stderrors.Is(e, err) || errors.ClassOf(e) != "" || !errors.Inspect(e).Retryable {
+		t.Errorf("failed: retryable changes error %v", e)
+	}
+
+	if errors.ErrRetryable(nil) != nil {
+		t.Errorf("failed: retryable nil")
+	}
+}
+
+// netError mimics net.Error
+type netError struct{ temporary bool }
+
+func (e netError) Error() string   { return "net" }
+func (e netError) Timeout() bool   { return false }
+func (e netError) Temporary() bool { return e.temporary }
+
+func TestTemporary(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrTemporary(err))
+	if !errors.IsTemporary(e) || errors.IsTemporary(errA.With(err)) || !errors.Inspect(e).Temporary {
+		t.Errorf("failed: temporary %v", e)
+	}
+
+	if !errors.IsTemporary(errA.With(netError{true})) || errors.IsTemporary(errA.With(netError{false})) {
+		t.Errorf("failed: temporary net.Error")
+	}
+
+	if !stderrors.Is(e, err) || errors.ErrTemporary(nil) != nil {
+		t.Errorf("failed: temporary %v", e)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	const errA = errors.Type("a")
+
+	name := errors.Violation{Field: "name", Rule: "required", Message: "is required"}
+	age := errors.Violation{Field: "age", Rule: "min", Message: "must be positive"}
+
+	e := errA.With(errors.ErrValidation(nil, name, age))
+	if !errors.IsValidation(e) || errors.IsValidation(errA.With(err)) {
+		t.Errorf("failed: validation %v", e)
+	}
+
+	if seq, ok := errors.ViolationsOf(e); !ok || len(seq) != 2 || seq[0] != name || seq[1] != age {
+		t.Errorf("failed: violations %v", seq)
+	}
+
+	if msg := errors.ErrValidation(nil, name, age).Error(); msg != "invalid name: is required; age: must be positive" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	if e := errors.ErrValidation(err, name); !stderrors.Is(e, err) || e.Error() != "invalid name: is required: just error" {
+		t.Errorf("failed: %v", e)
+	}
+
+	if e := errors.ErrValidation(err); e != err || errors.IsValidation(e) {
+		t.Errorf("failed: validation without violations %v", e)
+	}
+
+	if errors.ErrValidation(nil) != nil {
+		t.Errorf("failed: validation of valid input")
+	}
+}
+
+func TestTransient(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.Transient(err))
+	if !errors.IsTransient(e) || errors.IsPermanent(e) || !stderrors.Is(e, err) {
+		t.Errorf("failed: transient %v", e)
+	}
+
+	e = errA.With(errors.Permanent(e))
+	if errors.IsTransient(e) || !errors.IsPermanent(e) {
+		t.Errorf("failed: permanent %v", e)
+	}
+
+	if errors.IsTransient(errA.With(err)) || errors.IsPermanent(errA.With(err)) {
+		t.Errorf("failed: unknown is classified")
+	}
+
+	if errors.Transient(nil) != nil || errors.Permanent(nil) != nil {
+		t.Errorf("failed: nil")
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrHTTPStatus(err, 429))
+	if !errors.IsStatus(e, 503, 429) || errors.IsStatus(e, 503) || !errors.IsStatus(e) {
+		t.Errorf("failed: status %v", e)
+	}
+
+	if !errors.IsStatusCode(e, "429") || errors.Inspect(e).StatusCode != "429" || !stderrors.Is(e, err) {
+		t.Errorf("failed: status code %v", e)
+	}
+
+	if status, ok := errors.StatusOf(errA.With(errors.ErrStatusCode(err, "404"))); !ok || status != 404 {
+		t.Errorf("failed: status of %v", status)
+	}
+
+	if errors.IsStatus(errA.With(errors.ErrStatusCode(err, "E42"))) || errors.IsStatus(errA.With(err)) {
+		t.Errorf("failed: status of non numeric code")
+	}
+
+	if errors.ErrHTTPStatus(nil, 500) != nil {
+		t.Errorf("failed: status nil")
+	}
+}
+
+func TestConflictOf(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrConflictOn(err, "k"))
+	if key, ok := errors.ConflictOf(e); !ok || key != "k" || !errors.IsConflict(e) {
+		t.Errorf("failed: conflict of %v", e)
+	}
+
+	if _, ok := errors.ConflictOf(errA.With(errors.ErrConflict(err))); ok {
+		t.Errorf("failed: conflict without key")
+	}
+
+	if !stderrors.Is(e, err) || errors.ClassOf(e) != errors.ClassConflict || errors.ErrConflictOn(nil, "k") != nil {
+		t.Errorf("failed: conflict %v", e)
+	}
+}
+
+func TestBehaviors(t *testing.T) {
+	const errA = errors.Type("a")
+
+	for _, tt := range []struct {
+		err   error
+		check func(error) bool
+	}{
+		{errors.ErrNotFound(err, "k"), func(e error) bool { return errors.IsNotFound(e, "k") }},
+		{errors.ErrConflict(err), errors.IsConflict},
+		{errors.ErrPreConditionFailed(err), errors.IsPreConditionFailed},
+		{errors.ErrGone(err), errors.IsGone},
+		{errors.ErrTimeout(err, time.Second), func(e error) bool { return errors.IsTimeout(e, time.Second) }},
+		{errors.ErrStatusCode(err, "500"), func(e error) bool { return errors.IsStatusCode(e, "500") }},
+	} {
+		e := errA.With(tt.err)
+		if !tt.check(e) || !stderrors.Is(e, err) || tt.err.Error() != err.Error() {
+			t.Errorf("failed: %v", e)
+		}
+	}
+
+	for _, e := range []error{
+		errors.ErrNotFound(nil, "k"),
+		errors.ErrConflict(nil),
+		errors.ErrPreConditionFailed(nil),
+		errors.ErrGone(nil),
+		errors.ErrTimeout(nil, time.Second),
+		errors.ErrStatusCode(nil, "500"),
+	} {
+		if e != nil {
+			t.Errorf("failed: %v is not nil", e)
+		}
+	}
+}
+
+func TestBehaviorsAs(t *testing.T) {
+	const errA = errors.Type("a")
+
+	var nf errors.NotFoundError
+	if !stderrors.As(errA.With(errors.ErrNotFound(err, "k")), &nf) || nf.Key() != "k" {
+		t.Errorf("failed: not found %v", nf)
+	}
+
+	var to errors.TimeoutError
+	if !stderrors.As(errA.With(errors.ErrTimeout(err, time.Second)), &to) || to.Timeout() != time.Second {
+		t.Errorf("failed: timeout %v", to)
+	}
+
+	var sc errors.StatusCodeError
+	if !stderrors.As(errA.With(errors.ErrStatusCode(err, "500")), &sc) || sc.StatusCode() != "500" {
+		t.Errorf("failed: status code %v", sc)
+	}
+
+	// zero values are safe
+	if (errors.NotFoundError{}).Error() != "not found" || (errors.DownError{}).Error() != "down" {
+		t.Errorf("failed: zero values")
+	}
+}
+
+func TestNearTimeout(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrNearTimeout(err, time.Second))
+	if !errors.IsNearTimeout(e) || errors.IsTimeout(e, 0) || errors.ClassOf(e) != errors.ClassNearTimeout {
+		t.Errorf("failed: near timeout %v", e)
+	}
+
+	if errors.ErrNearTimeout(nil, time.Second) != nil {
+		t.Errorf("failed: near timeout nil")
+	}
+
+	for _, tt := range []struct {
+		elapsed time.Duration
+		class   errors.Class
+	}{
+		{5 * time.Second, errors.ClassTimeout},
+		{4 * time.Second, errors.ClassTimeout},
+		{3500 * time.Millisecond, errors.ClassNearTimeout},
+		{time.Second, ""},
+	} {
+		for _, cause := range []error{err, nil} {
+			e := errA.WithElapsed(cause, tt.elapsed, 4*time.Second)
+			if errors.ClassOf(e) != tt.class || (cause != nil && !stderrors.Is(e, err)) {
+				t.Errorf("failed: %v %v", tt.elapsed, e)
+			}
+		}
+	}
+
+	if e := errA.WithElapsed(nil, time.Millisecond, time.Second); e != nil {
+		t.Errorf("failed: success is the fault %v", e)
+	}
+
+	if x := errors.Inspect(errA.WithElapsed(err, 3500*time.Millisecond, 4*time.Second)); x.NearTimeout != 3500*time.Millisecond {
+		t.Errorf("failed: %+v", x)
+	}
+}
+
+func TestEnsureClassified(t *testing.T) {
+	const errA = errors.Type("a")
+
+	conflict := errA.With(errors.ErrConflict(err))
+	if e := errors.EnsureClassified(conflict, errors.ClassInternal); e != conflict {
+		t.Errorf("failed: classified error is changed %v", e)
+	}
+
+	e := errors.EnsureClassified(errA.With(err), errors.ClassInternal)
+	if errors.ClassOf(e) != errors.ClassInternal || !stderrors.Is(e, errA) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if x := errors.EnsureClassified(e, errors.ClassDown); x != e {
+		t.Errorf("failed: not idempotent %v", x)
+	}
+
+	if errors.EnsureClassified(nil, errors.ClassInternal) != nil {
+		t.Errorf("failed: nil")
+	}
+}
+
+func TestNotFoundOf(t *testing.T) {
+	const errA = errors.Type("a")
+
+	type id struct {
+		Tenant string
+		Seq    int
+	}
+
+	e := errA.With(errors.ErrNotFoundOf(err, id{"t", 1}))
+	if !errors.IsNotFoundKey(e, id{"t", 1}) || errors.IsNotFoundKey(e, id{"t", 2}) || !errors.IsNotFoundKey[id](e) {
+		t.Errorf("failed: not found of %v", e)
+	}
+
+	if errors.IsNotFoundKey(e, "{t 1}") || errors.IsNotFoundKey[id](errA.With(errors.ErrNotFound(err, "k"))) {
+		t.Errorf("failed: not found of other key type")
+	}
+
+	if !errors.IsNotFound(e, "{t 1}") || errors.ClassOf(e) != errors.ClassNotFound || !stderrors.Is(e, err) {
+		t.Errorf("failed: not found %v", e)
+	}
+
+	if errors.ErrNotFoundOf(nil, 1) != nil {
+		t.Errorf("failed: not found of nil")
+	}
+}
@@ -0,0 +1,117 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Codec transfers faults over custom protocols (e.g. NATS headers, GraphQL
+// error extensions). External packages implement and register codecs,
+// the library does not depend on them.
+type Codec interface {
+	// Encode fault into the protocol representation
+	Encode(Fault) ([]byte, error)
+
+	// Decode fault from the protocol representation
+	Decode([]byte) (Fault, error)
+}
+
+var (
+	muCodecs sync.RWMutex
+	codecs   = map[string]Codec{}
+)
+
+// RegisterCodec makes the codec available by the protocol name.
+// It panics if the codec is registered twice, similarly to sql.Register.
+//
+//	func init() {
+//		faults.RegisterCodec("nats", natsCodec{})
+//	}
+func RegisterCodec(protocol string, codec Codec) {
+	muCodecs.Lock()
+	defer muCodecs.Unlock()
+
+	if codec == nil {
+		panic("faults: codec is nil")
+	}
+
+	if _, has := codecs[protocol]; has {
+		panic("faults: codec " + protocol + " is registered twice")
+	}
+
+	codecs[protocol] = codec
+}
+
+// Codecs returns sorted list of registered protocols.
+func Codecs() []string {
+	muCodecs.RLock()
+	defer muCodecs.RUnlock()
+
+	seq := make([]string, 0, len(codecs))
+	for protocol := range codecs {
+		seq = append(seq, protocol)
+	}
+	sort.Strings(seq)
+
+	return seq
+}
+
+func codecOf(protocol string) (Codec, error) {
+	muCodecs.RLock()
+	defer muCodecs.RUnlock()
+
+	codec, has := codecs[protocol]
+	if !has {
+		return nil, fmt.Errorf("faults: unknown codec %q", protocol)
+	}
+
+	return codec, nil
+}
+
+// Encode the error using the codec of the protocol. Errors produced outside
+// of the library are encoded as faults with the message of the error.
+// Hops are unwrapped, the codec receives the fault that still tells the path
+// (see Path).
+func Encode(protocol string, err error) ([]byte, error) {
+	codec, cerr := codecOf(protocol)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	if err == nil {
+		return nil, nil
+	}
+
+	fault, ok := unhop(err).(Fault)
+	if !ok {
+		text := err.Error()
+		x := &errType{head: err}
+		x.text.Store(&text)
+		fault = x
+	}
+
+	if _, ok := err.(*hop); ok {
+		fault = &hopped{Fault: fault, err: err}
+	}
+
+	return codec.Encode(fault)
+}
+
+// Decode the error using the codec of the protocol.
+func Decode(protocol string, data []byte) (Fault, error) {
+	codec, err := codecOf(protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.Decode(data)
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+// codec transfers message only
+type text struct{}
+
+func (text) Encode(f errors.Fault) ([]byte, error) { return []byte(f.Message()), nil }
+
+func (text) Decode(b []byte) (errors.Fault, error) {
+	var f errors.Fault
+	stderrors.As(errors.Fast(string(b)).With(nil), &f)
+	return f, nil
+}
+
+func TestCodec(t *testing.T) {
+	errors.RegisterCodec("text", text{})
+
+	if seq := errors.Codecs(); len(seq) != 1 || seq[0] != "text" {
+		t.Errorf("failed: %v", seq)
+	}
+
+	b, e := errors.Encode("text", errors.Safe1[int]("a %d").With(err, 1))
+	if e != nil || string(b) != "a 1" {
+		t.Errorf("failed: %s %v", b, e)
+	}
+
+	b, e = errors.Encode("text", err)
+	if e != nil || string(b) != "just error" {
+		t.Errorf("failed: %s %v", b, e)
+	}
+
+	f, e := errors.Decode("text", []byte("a 1"))
+	if e != nil || f.Error() != "a 1" {
+		t.Errorf("failed: %v %v", f, e)
+	}
+
+	if _, e := errors.Encode("none", err); e == nil {
+		t.Errorf("failed: unknown codec")
+	}
+
+	if _, e := errors.Decode("none", nil); e == nil {
+		t.Errorf("failed: unknown codec")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("failed: no panic")
+		}
+	}()
+	errors.RegisterCodec("text", text{})
+}
+
+// codec transfers message and path
+type hops struct{}
+
+func (hops) Encode(f errors.Fault) ([]byte, error) {
+	return []byte(f.Message() + "|" + strings.Join(errors.Path(f), ",")), nil
+}
+
+func (hops) Decode(b []byte) (errors.Fault, error) {
+	msg, _, _ := strings.Cut(string(b), "|")
+	return text{}.Decode([]byte(msg))
+}
+
+func TestCodecPath(t *testing.T) {
+	errors.RegisterCodec("hops", hops{})
+
+	e := errors.Hop(errors.Hop(errors.Safe1[int]("a %d").With(err, 1), "ledger"), "billing")
+
+	b, x := errors.Encode("hops", e)
+	if x != nil || string(b) != "a 1|billing,ledger" {
+		t.Errorf("failed: %s %v", b, x)
+	}
+
+	f, x := errors.Decode("hops", b)
+	if x != nil {
+		t.Fatalf("failed: %v", x)
+	}
+
+	// transport restores the path after decode
+	_, path, _ := strings.Cut(string(b), "|")
+	seq := strings.Split(path, ",")
+
+	var restored error = f
+	for i := len(seq) - 1; i >= 0; i-- {
+		restored = errors.Hop(restored, seq[i])
+	}
+
+	if fmt.Sprint(errors.Path(restored)) != fmt.Sprint(errors.Path(e)) || restored.Error() != "a 1" {
+		t.Errorf("failed: %v %v", errors.Path(restored), restored)
+	}
+
+	b, x = errors.Encode("hops", errors.Hop(err, "ledger"))
+	if x != nil || string(b) != "just error|ledger" {
+		t.Errorf("failed: %s %v", b, x)
+	}
+}
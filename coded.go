@@ -0,0 +1,145 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"errors"
+	"strings"
+)
+
+// Coded creates a context for the error with stable machine-readable code
+// alongside human text, APIs and dashboards key off codes instead of
+// messages. The code precedes the text, separated by ": ".
+// The context produces an error like `[function line] text: original error`.
+//
+//	const errSome = faults.Coded("E1042: unable to do something")
+type Coded string
+
+// Code declares the context with the code, see Coded
+//
+//	var errSome = faults.Code("E1042", "unable to do something")
+func Code(code, text string) Coded { return Coded(code + codeSeparator + text) }
+
+const codeSeparator = ": "
+
+// split the declaration into the code and the text
+func (e Coded) split() (string, string) {
+	code, text, has := strings.Cut(string(e), codeSeparator)
+	if !has {
+		return "", string(e)
+	}
+	return code, text
+}
+
+// With wraps error into the context.
+// The function expands the context with arguments.
+//
+//	if err := doSomething(); err != nil {
+//		return nil, errSome.With(err)
+//	}
+func (e Coded) With(err error, args ...any) error {
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (e Coded) Maybe(err error, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// WithAll wraps multiple errors into the context, see Type.WithAll
+func (e Coded) WithAll(errs ...error) error {
+	return withCaller(0, newErrType(e, joinErrs(errs), nil))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (e Coded) WrapOnce(err error, args ...any) error {
+	if carries(err, e) {
+		return err
+	}
+
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// Must panics with the error wrapped into the context, see Type.Must
+func (e Coded) Must(err error, args ...any) {
+	if err == nil {
+		return
+	}
+
+	panic(withCaller(0, newErrType(e, err, args)))
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (e Coded) Check(err error) bool { return errors.Is(err, e) }
+
+// ErrCode is the code of the context
+func (e Coded) ErrCode() string { code, _ := e.split(); return code }
+
+func (e Coded) Error() string { _, text := e.split(); return text }
+func (e Coded) arity() int    { return -1 }
+func (e Coded) zero() string  { return e.Error() }
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (e Coded) MatchAlso(errs ...error) Coded {
+	matchAlso(e, errs)
+	return e
+}
+
+// Enum declares coded contexts for the existing string enum of error reasons,
+// one context per value. The code of the context is the value, the text is
+// given by the map, the value is used if the text is empty.
+//
+//	type Reason string
+//
+//	const (
+//		ReasonNoStock Reason = "NO_STOCK"
+//		ReasonExpired Reason = "EXPIRED"
+//	)
+//
+//	var errReason = faults.Enum(map[Reason]string{
+//		ReasonNoStock: "item is out of stock",
+//		ReasonExpired: "offer is expired",
+//	})
+//
+//	errReason[ReasonNoStock].With(err)
+func Enum[T ~string](texts map[T]string) map[T]Coded {
+	decls := make(map[T]Coded, len(texts))
+	for reason, text := range texts {
+		if text == "" {
+			text = string(reason)
+		}
+		decls[reason] = Code(string(reason), text)
+	}
+
+	return decls
+}
+
+// CodeOf returns the code of the first error in the chain exposing it via
+// `ErrCode() string`, empty if the chain is not coded.
+//
+//	switch faults.CodeOf(err) {
+//	case "E1042":
+//	}
+func CodeOf(err error) string {
+	var code string
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ ErrCode() string }); ok && e.ErrCode() != "" {
+			code = e.ErrCode()
+			return false
+		}
+		return true
+	})
+
+	return code
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"bytes"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+const (
+	errCodedA = errors.Coded("E1042: unable to do %s")
+	errCodedB = errors.Coded("E1043: unable to do something")
+)
+
+func TestCoded(t *testing.T) {
+	e := errCodedA.With(err, "a")
+
+	if e.Error() != at("TestCoded", 26)+"unable to do a: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if !errCodedA.Check(e) || errCodedB.Check(e) || !stderrors.Is(e, err) {
+		t.Errorf("failed: %v", e)
+	}
+
+	var fault errors.Fault
+	if !stderrors.As(e, &fault) || fault.Code() != "E1042" {
+		t.Errorf("failed: %v", fault)
+	}
+
+	if errCodedA.Maybe(nil) != nil {
+		t.Errorf("failed: maybe")
+	}
+
+	if e := errCodedB.WithAll(err, nil); !errCodedB.Check(e) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if ref := errors.Ref(e); ref.Code != "E1042" {
+		t.Errorf("failed: %v", ref)
+	}
+
+	if errors.Code("E1042", "unable to do %s") != errCodedA {
+		t.Errorf("failed: code declaration")
+	}
+
+	if e := errors.Coded("unable: x").With(nil); errors.CodeOf(e) != "unable" || e.(errors.Fault).Message() != "x" {
+		t.Errorf("failed: %v", e)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	const errA = errors.Type("a")
+
+	if code := errors.CodeOf(errA.With(errCodedB.With(err))); code != "E1043" {
+		t.Errorf("failed: %s", code)
+	}
+
+	if code := errors.CodeOf(errCodedA.With(errCodedB.With(err), "a")); code != "E1042" {
+		t.Errorf("failed: %s", code)
+	}
+
+	if code := errors.CodeOf(errA.With(err)); code != "" {
+		t.Errorf("failed: %s", code)
+	}
+
+	if code := errors.CodeOf(nil); code != "" {
+		t.Errorf("failed: %s", code)
+	}
+}
+
+func TestCodedExport(t *testing.T) {
+	catalog := errors.Catalog{errors.Type("a"), errCodedA}
+
+	var buf bytes.Buffer
+	if err := catalog.WriteJSONSchema(&buf); err != nil || !strings.Contains(buf.String(), `"E1042"`) {
+		t.Errorf("failed: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := catalog.WriteTypeScript(&buf); err != nil || !strings.Contains(buf.String(), `"unable to do %s": { arity: -1, code: "E1042" },`) {
+		t.Errorf("failed: %s", buf.String())
+	}
+}
+
+type reason string
+
+const (
+	reasonNoStock reason = "NO_STOCK"
+	reasonExpired reason = "EXPIRED"
+)
+
+func TestEnum(t *testing.T) {
+	errReason := errors.Enum(map[reason]string{
+		reasonNoStock: "item is out of stock",
+		reasonExpired: "",
+	})
+
+	e := errReason[reasonNoStock].With(err)
+	if !errReason[reasonNoStock].Check(e) || errReason[reasonExpired].Check(e) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if reason(errors.CodeOf(e)) != reasonNoStock || !strings.HasSuffix(e.Error(), "item is out of stock: just error") {
+		t.Errorf("failed: %v", e)
+	}
+
+	if x := errReason[reasonExpired]; x.ErrCode() != "EXPIRED" || x.Error() != "EXPIRED" {
+		t.Errorf("failed: %v", x)
+	}
+
+	if !stderrors.Is(e, errors.Code("NO_STOCK", "item is out of stock")) {
+		t.Errorf("failed: %v", e)
+	}
+}
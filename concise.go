@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// concise renders well-known stdlib errors in the concise form
+func concise(err error) string {
+	switch e := err.(type) {
+	case *fs.PathError:
+		return e.Op + " " + e.Path + ": " + errno(e.Err)
+	case *url.Error:
+		return strings.ToUpper(e.Op) + " " + e.URL + ": " + concise(e.Err)
+	default:
+		return err.Error()
+	}
+}
+
+// errno renders file system errors as POSIX error names
+func errno(err error) string {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return "ENOENT"
+	case errors.Is(err, fs.ErrPermission):
+		return "EACCES"
+	case errors.Is(err, fs.ErrExist):
+		return "EEXIST"
+	default:
+		return err.Error()
+	}
+}
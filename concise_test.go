@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestConcise(t *testing.T) {
+	defer errors.Configure(errors.Concise(false))
+
+	const errA = errors.Fast("a")
+
+	_, cause := os.Open("/faults-do-not-exist")
+	web := &url.Error{Op: "Get", URL: "http://x", Err: &fs.PathError{Op: "read", Path: "/y", Err: fs.ErrPermission}}
+
+	errors.Configure(errors.Concise(true))
+
+	if e := errA.With(cause); e.Error() != "a: open /faults-do-not-exist: ENOENT" || !stderrors.Is(e, fs.ErrNotExist) {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errA.With(web); e.Error() != "a: GET http://x: read /y: EACCES" || !stderrors.Is(e, fs.ErrPermission) {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errA.With(err); e.Error() != "a: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	errors.Configure(errors.Concise(false))
+
+	if e := errA.With(cause); e.Error() != "a: open /faults-do-not-exist: no such file or directory" {
+		t.Errorf("failed: %s", e)
+	}
+}
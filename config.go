@@ -0,0 +1,333 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// Option configures the library behavior, see Configure.
+type Option func(*config)
+
+type config struct {
+	argsPolicy   ArgsPolicy
+	maxArgLength int
+	maxArgs      int
+	concise      bool
+	overrides    bool
+	timestamps   bool
+	pooling      bool
+	noCallers    bool
+	aboutNone    bool
+	skipCallers  bool
+	clock        func() time.Time
+	about        func(runtime.Frame) string
+	observers    []func(Fault)
+	build        *BuildInfo
+}
+
+var cfg atomic.Pointer[config]
+
+func init() {
+	cfg.Store(&config{
+		maxArgLength: 256,
+		clock:        time.Now,
+		about:        AboutFunction,
+	})
+}
+
+// Configure the library. The configuration is global, it is expected to be
+// called once at the application startup. Options not given are preserved.
+//
+//	faults.Configure(
+//		faults.OnArgsMismatch(faults.ArgsPanic),
+//	)
+func Configure(opts ...Option) {
+	c := *cfg.Load()
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	// callers are not captured if no one is consuming them
+	c.skipCallers = c.noCallers || (c.aboutNone && len(c.observers) == 0)
+
+	cfg.Store(&c)
+}
+
+// ArgsPolicy is invoked when the number of arguments does not match verbs
+// of the template. The returned note is appended to the message.
+type ArgsPolicy func(template string, want, got int) string
+
+// OnArgsMismatch sets the policy applied when the number of arguments does
+// not match verbs of the template. The mismatch is silent by default,
+// the message contains fmt noise (e.g. %!s(MISSING)) only.
+func OnArgsMismatch(policy ArgsPolicy) Option {
+	return func(c *config) { c.argsPolicy = policy }
+}
+
+// ArgsPanic panics on mismatched arguments, use it in development.
+func ArgsPanic(template string, want, got int) string {
+	panic(fmt.Sprintf("faults: template %q expects %d args, got %d", template, want, got))
+}
+
+// ArgsNote appends the note about mismatched arguments to the message.
+func ArgsNote(template string, want, got int) string {
+	return fmt.Sprintf(" (faults: template expects %d args, got %d)", want, got)
+}
+
+// MaxArgLength limits the length of each rendered argument (256 bytes by
+// default). Longer arguments are truncated in the middle, so passing a huge
+// payload as an argument does not produce multi-megabyte messages. Shorter
+// arguments are rendered as is, truncated ones lose %T and %p verbs.
+// Zero disables the limit.
+func MaxArgLength(n int) Option {
+	return func(c *config) { c.maxArgLength = n }
+}
+
+// MaxArgs caps the number of arguments retained by the fault, excess is
+// dropped and counted by the field "dropped_args" (see Fields). Arguments
+// consumed by the template are always retained. It defends against call
+// sites that splat entire slices into arguments of With.
+// Zero disables the cap, it is default.
+//
+//	faults.Configure(faults.MaxArgs(8))
+func MaxArgs(n int) Option {
+	return func(c *config) { c.maxArgs = n }
+}
+
+// Concise collapses well-known noisy stdlib errors (fs.PathError, url.Error)
+// into concise forms when faults render their causes, e.g.
+// "open /x: no such file or directory" becomes "open /x: ENOENT".
+// Errors remain matchable by errors.Is and errors.As.
+func Concise(enabled bool) Option {
+	return func(c *config) { c.concise = enabled }
+}
+
+// Overrides permits runtime overrides of public messages, see Override.
+// Overrides are disabled by default.
+func Overrides(enabled bool) Option {
+	return func(c *config) { c.overrides = enabled }
+}
+
+// Timestamps enables capture of the creation time of faults, see TimeOf.
+func Timestamps(enabled bool) Option {
+	return func(c *config) { c.timestamps = enabled }
+}
+
+// Clock replaces the clock used to timestamp faults, use it in tests.
+// Nil restores time.Now.
+func Clock(clock func() time.Time) Option {
+	return func(c *config) {
+		c.clock = clock
+		if clock == nil {
+			c.clock = time.Now
+		}
+	}
+}
+
+// Callers switches the caller capture of all contexts (Type, Deep, SafeN)
+// at once, disabled capture turns them into Fast. The capture is enabled by
+// default, build tag faults_nocaller disables it at compile time.
+//
+//	faults.Configure(faults.Callers(false))
+func Callers(enabled bool) Option {
+	return func(c *config) { c.noCallers = !enabled }
+}
+
+// About customizes rendering of the caller, the prefix of the error message
+// `[function line]` by default. The empty string omits the prefix.
+// Nil restores the default.
+//
+//	faults.Configure(faults.About(faults.AboutFileLine))
+func About(about func(runtime.Frame) string) Option {
+	return func(c *config) {
+		c.about = about
+		if about == nil {
+			c.about = AboutFunction
+		}
+		c.aboutNone = reflect.ValueOf(c.about).Pointer() == reflect.ValueOf(AboutNone).Pointer()
+	}
+}
+
+// AboutNone omits the caller, messages are rendered as `text: original error`.
+// Contexts do not capture callers at all unless observers are registered,
+// Type performs as Fast without changing declarations.
+//
+//	faults.Configure(faults.About(faults.AboutNone))
+func AboutNone(runtime.Frame) string { return "" }
+
+// AboutFunction renders the caller as `[github.com/some/pkg.Function 123]`
+func AboutFunction(frame runtime.Frame) string {
+	return "[" + frame.Function + " " + strconv.Itoa(frame.Line) + "]"
+}
+
+// AboutShortFunction renders the caller without the module path as
+// `[pkg.Function 123]`
+func AboutShortFunction(frame runtime.Frame) string {
+	name := frame.Function
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return "[" + name + " " + strconv.Itoa(frame.Line) + "]"
+}
+
+// AboutFileLine renders the caller as `file.go:123` that editors click through
+func AboutFileLine(frame runtime.Frame) string {
+	return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+}
+
+// sprintf renders the template with arguments applying args policy.
+func sprintf(template string, args []any) string {
+	c := cfg.Load()
+
+	msg := template
+	if len(args) > 0 {
+		if c.maxArgLength > 0 {
+			args = truncateArgs(args, c.maxArgLength)
+		}
+		msg = fmt.Sprintf(template, args...)
+	}
+
+	if policy := c.argsPolicy; policy != nil {
+		if want, ok := verbs(template); ok && want != len(args) {
+			msg += policy(template, want, len(args))
+		}
+	}
+
+	return msg
+}
+
+func truncateArgs(args []any, max int) []any {
+	var seq []any
+
+	for i, arg := range args {
+		var x any
+
+		switch v := arg.(type) {
+		case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			continue
+		case string:
+			if len(v) <= max {
+				continue
+			}
+			x = truncated{v, max}
+		default:
+			if len(fmt.Sprint(v)) <= max {
+				continue
+			}
+			x = truncated{v, max}
+		}
+
+		if seq == nil {
+			seq = make([]any, len(args))
+			copy(seq, args)
+		}
+		seq[i] = x
+	}
+
+	if seq == nil {
+		return args
+	}
+	return seq
+}
+
+// truncated renders the value, cutting its middle if it is longer than max.
+type truncated struct {
+	value any
+	max   int
+}
+
+const ellipsis = "…"
+
+func (t truncated) Format(s fmt.State, verb rune) {
+	str := fmt.Sprintf(fmt.FormatString(s, verb), t.value)
+	if len(str) <= t.max || t.max <= len(ellipsis) {
+		io.WriteString(s, str)
+		return
+	}
+
+	head := (t.max - len(ellipsis)) / 2
+	tail := len(str) - (t.max - len(ellipsis) - head)
+
+	for head > 0 && !utf8.RuneStart(str[head]) {
+		head--
+	}
+	for tail < len(str) && !utf8.RuneStart(str[tail]) {
+		tail++
+	}
+
+	io.WriteString(s, str[:head])
+	io.WriteString(s, ellipsis)
+	io.WriteString(s, str[tail:])
+}
+
+// maxArgsOf returns the cap of arguments, arguments consumed by the template
+// of the declaration are always retained.
+func maxArgsOf(head error) int {
+	max := cfg.Load().maxArgs
+	if max <= 0 {
+		return max
+	}
+
+	if want, ok := verbs(head.Error()); ok && want > max {
+		return want
+	}
+
+	return max
+}
+
+// capArgs retains first max arguments, it returns the number of dropped ones
+func capArgs(args []any, max int) ([]any, int) {
+	if max <= 0 || len(args) <= max {
+		return args, 0
+	}
+
+	return args[:max:max], len(args) - max
+}
+
+// verbs counts arguments consumed by the template. It is not able
+// to count templates with explicit argument indexes.
+func verbs(template string) (int, bool) {
+	n := 0
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+
+		// flags, width and precision
+		for i++; i < len(template); i++ {
+			c := template[i]
+			if c == '[' {
+				return 0, false
+			}
+			if c == '*' {
+				n++
+				continue
+			}
+			if c != '+' && c != '-' && c != '#' && c != ' ' && c != '0' && c != '.' && (c < '1' || c > '9') {
+				break
+			}
+		}
+
+		if i < len(template) && template[i] != '%' {
+			n++
+		}
+	}
+
+	return n, true
+}
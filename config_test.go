@@ -0,0 +1,186 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestArgsPolicy(t *testing.T) {
+	defer errors.Configure(errors.OnArgsMismatch(nil))
+
+	const (
+		errA = errors.Fast("a %s %d")
+		errB = errors.Fast("100%% of %*d")
+		errC = errors.Fast("a %[1]s %[1]s")
+	)
+
+	errors.Configure(errors.OnArgsMismatch(errors.ArgsNote))
+
+	if e := errA.With(err, "a", 1); e.Error() != "a a 1: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errA.With(err, "a"); e.Error() != "a a %!d(MISSING) (faults: template expects 2 args, got 1): just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errB.With(err, 5, 1); e.Error() != "100% of     1: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errC.With(err, "a"); e.Error() != "a a a: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	errors.Configure(errors.OnArgsMismatch(errors.ArgsPanic))
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("failed: no panic")
+		}
+	}()
+
+	_ = errA.With(err, "a", 1, 2).Error()
+}
+
+func TestMaxArgLength(t *testing.T) {
+	defer errors.Configure(errors.MaxArgLength(256))
+
+	const errA = errors.Fast("a %s %d %v")
+
+	errors.Configure(errors.MaxArgLength(9))
+
+	if e := errA.With(err, "0123456789", 1234567890123, []int{1, 2, 3, 4, 5}); e.Error() != "a 012…789 1234567890123 [1 … 5]: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errA.With(err, "ééééé", 1, "ab"); e.Error() != "a é…é 1 ab: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errA.With(err, "01234", 1, 2); e.Error() != "a 01234 1 2: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errors.Fast("t %T %v").With(err, entity{"a", "b"}, &entity{"a", "b"}); e.Error() != "t faults_test.entity &{a b}: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	errors.Configure(errors.MaxArgLength(0))
+
+	if e := errA.With(err, "0123456789", 1, 2); e.Error() != "a 0123456789 1 2: just error" {
+		t.Errorf("failed: %s", e)
+	}
+}
+
+func TestMaxArgs(t *testing.T) {
+	defer errors.Configure(errors.MaxArgs(0))
+
+	const errA = errors.Fast("a %v %v %v")
+
+	errors.Configure(errors.MaxArgs(2))
+
+	ids := []any{1, 2, 3, 4, 5}
+	e := errA.With(err, ids...)
+	if args := e.(errors.Fault).Args(); len(args) != 3 {
+		t.Errorf("failed: %v", args)
+	}
+
+	if fields := errors.Fields(e); fmt.Sprint(fields) != "[{dropped_args 2}]" {
+		t.Errorf("failed: %v", fields)
+	}
+
+	if e.Error() != "a 1 2 3: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if e := errA.With(err, 1, 2); e.Error() != "a 1 2 %!v(MISSING): just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	errors.Configure(errors.MaxArgs(0))
+
+	if args := errA.With(err, ids...).(errors.Fault).Args(); len(args) != 5 {
+		t.Errorf("failed: %v", args)
+	}
+}
+
+func TestCallers(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Safe1[int]("b %d")
+		errC = errors.Deep("c")
+	)
+
+	errors.Configure(errors.Callers(false))
+	defer errors.Configure(errors.Callers(true))
+
+	e := errA.With(errB.With(errC.With(err), 1))
+	if e.Error() != "a: b 1: c: just error" || errors.StackOf(e) != nil {
+		t.Errorf("failed: %v", e)
+	}
+
+	if !errA.Check(e) || !errB.Check(e) || !errC.Check(e) {
+		t.Errorf("failed: %v", e)
+	}
+}
+
+func TestAboutNone(t *testing.T) {
+	const errA = errors.Type("a")
+
+	errors.Configure(errors.About(errors.AboutNone))
+	defer errors.Configure(errors.About(nil))
+
+	e := errA.With(err)
+	if name, _ := e.(errors.Fault).Caller(); withCallers && name != "" || e.Error() != "a: just error" {
+		t.Errorf("failed: caller is captured %v", e)
+	}
+
+	errors.Configure(errors.Observe(func(errors.Fault) {}))
+	defer errors.Configure(errors.Observe())
+
+	e = errA.With(err)
+	if name, _ := e.(errors.Fault).Caller(); (withCallers && name != "github.com/fogfish/faults_test.TestAboutNone") || e.Error() != "a: just error" {
+		t.Errorf("failed: caller is not captured for observers %v", e)
+	}
+}
+
+func TestAbout(t *testing.T) {
+	requireCallers(t)
+
+	const errA = errors.Type("a")
+
+	for _, tt := range []struct {
+		about  func(runtime.Frame) string
+		expect string
+	}{
+		{errors.AboutShortFunction, "[faults_test.TestAbout LINE] a"},
+		{errors.AboutFileLine, "config_test.go:LINE a"},
+		{func(runtime.Frame) string { return "" }, "a"},
+	} {
+		errors.Configure(errors.About(tt.about))
+		msg := errA.With(nil).Error()
+		errors.Configure(errors.About(nil))
+
+		if msg != strings.ReplaceAll(tt.expect, "LINE", strconv.Itoa(175)) {
+			t.Errorf("failed: %s", msg)
+		}
+	}
+
+	if e := errA.With(nil); !strings.HasPrefix(e.Error(), "[github.com/fogfish/faults_test.TestAbout ") {
+		t.Errorf("failed: %s", e)
+	}
+}
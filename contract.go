@@ -0,0 +1,72 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"io"
+)
+
+// ErrContractBreach is the error returned by Contract.Verify
+const ErrContractBreach = Fast("error breaches the contract of %s")
+
+// Contract formalizes errors as a part of the package API. It lists faults
+// and foreign sentinels the package may return.
+//
+//	var Contract = faults.Contract{
+//		Package:   "github.com/some/pkg",
+//		Faults:    faults.Catalog{ErrNotFound, ErrConflict},
+//		Sentinels: []error{io.EOF},
+//	}
+type Contract struct {
+	Package   string
+	Faults    Catalog
+	Sentinels []error
+}
+
+// Verify returns nil if the error complies with the contract, otherwise
+// the error is wrapped with ErrContractBreach. Use it in tests.
+//
+//	_, err := pkg.Get(ctx, key)
+//	if err := pkg.Contract.Verify(err); err != nil {
+//		t.Error(err)
+//	}
+func (c Contract) Verify(err error) error {
+	if c.Faults.Declares(err, c.Sentinels...) {
+		return nil
+	}
+
+	return ErrContractBreach.With(err, c.Package)
+}
+
+// WriteDoc generates markdown documentation of the contract.
+func (c Contract) WriteDoc(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "## Errors of %s\n\n| Error | Args |\n| --- | --- |\n", c.Package); err != nil {
+		return err
+	}
+
+	for _, err := range c.Faults {
+		args := "variadic"
+		if decl, ok := err.(declaration); ok && decl.arity() >= 0 {
+			args = fmt.Sprintf("%d", decl.arity())
+		}
+
+		if _, err := fmt.Fprintf(w, "| `%s` | %s |\n", err.Error(), args); err != nil {
+			return err
+		}
+	}
+
+	for _, err := range c.Sentinels {
+		if _, err := fmt.Fprintf(w, "| `%s` | sentinel |\n", err.Error()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
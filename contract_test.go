@@ -0,0 +1,56 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestContract(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Safe1[int]("b %d")
+	)
+
+	contract := errors.Contract{
+		Package:   "pkg",
+		Faults:    errors.Catalog{errA, errB},
+		Sentinels: []error{io.EOF},
+	}
+
+	for _, e := range []error{nil, errA.With(err), errB.With(err, 1), io.EOF} {
+		if x := contract.Verify(e); x != nil {
+			t.Errorf("failed: %v", x)
+		}
+	}
+
+	x := contract.Verify(err)
+	if !stderrors.Is(x, errors.ErrContractBreach) || !stderrors.Is(x, err) {
+		t.Errorf("failed: %v", x)
+	}
+
+	if x.Error() != "error breaches the contract of pkg: just error" {
+		t.Errorf("failed: %v", x)
+	}
+
+	var buf bytes.Buffer
+	if err := contract.WriteDoc(&buf); err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	doc := "## Errors of pkg\n\n| Error | Args |\n| --- | --- |\n| `a` | variadic |\n| `b %d` | 1 |\n| `EOF` | sentinel |\n"
+	if buf.String() != doc {
+		t.Errorf("failed: %s", buf.String())
+	}
+}
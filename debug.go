@@ -0,0 +1,31 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build faults_debug
+
+package faults
+
+import "fmt"
+
+// The debug build (-tags faults_debug) detects mutation of fault's args
+// after the fault is created. Args are aliased by the fault, the mutation
+// causes messages that do not match what actually happened.
+type guard struct{ digest string }
+
+func digest(args []any) string { return fmt.Sprintf("%+v", args) }
+
+func seal(e *errType) *errType {
+	e.digest = digest(e.args)
+	return created(e)
+}
+
+func (e *errType) verify() {
+	if d := digest(e.args); d != e.digest {
+		panic(fmt.Sprintf("faults: args of %q are mutated after creation: %s != %s", e.head.Error(), e.digest, d))
+	}
+}
@@ -0,0 +1,36 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build faults_debug
+
+package faults_test
+
+import (
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestMutationGuard(t *testing.T) {
+	const errA = errors.Fast("a %v")
+
+	ids := []int{1, 2}
+	e := errA.With(err, ids)
+	if e.Error() != "a [1 2]: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("failed: mutation is not detected")
+		}
+	}()
+
+	ids[0] = 3
+	_ = e.Error()
+}
@@ -0,0 +1,26 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+// RecoverWith annotates the returned error with the context once, at the top
+// of the function, instead of wrapping it at every return statement. It is
+// no-op if the function returns nil. The error is annotated with the deferring
+// function as the caller.
+//
+//	func doSomething() (err error) {
+//		defer faults.RecoverWith(&err, errSome)
+//		...
+//	}
+func RecoverWith(err *error, errX Type, args ...any) {
+	if err == nil || *err == nil {
+		return
+	}
+
+	*err = withCaller(0, newErrType(errX, *err, args))
+}
@@ -0,0 +1,51 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+const errRecover = errors.Type("recover %s")
+
+func doRecover(fail bool) (cause error) {
+	defer errors.RecoverWith(&cause, errRecover, "a")
+
+	if fail {
+		return err
+	}
+
+	return nil
+}
+
+func TestRecoverWith(t *testing.T) {
+	if e := doRecover(false); e != nil {
+		t.Errorf("failed: %v", e)
+	}
+
+	e := doRecover(true)
+	if !stderrors.Is(e, errRecover) || !stderrors.Is(e, err) {
+		t.Errorf("failed: %v", e)
+	}
+
+	// line depends on the compiler (e.g. open-coded defers), it is either
+	// the return statement or the end of the function
+	if name, _ := e.(errors.Fault).Caller(); withCallers && name != "github.com/fogfish/faults_test.doRecover" {
+		t.Errorf("failed: %v", e)
+	}
+
+	if e.(errors.Fault).Message() != "recover a" {
+		t.Errorf("failed: %v", e)
+	}
+
+	errors.RecoverWith(nil, errRecover)
+}
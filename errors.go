@@ -16,9 +16,12 @@
 // it defines a type safe wrapping of errors.
 package faults
 
+//go:generate go run ./internal/gensafe -n 10 -o safe.go
+//go:generate go run ./internal/gensafe -n 10 -fast -o fastsafe.go
+
 import (
-	"fmt"
-	"runtime"
+	"errors"
+	"time"
 )
 
 // Type creates a basic context for the error. The context produces an error like
@@ -28,36 +31,107 @@ import (
 type Type string
 
 // With wraps error into the context.
-// The function expands the context with arguments.
+// The function expands the context with arguments. It always produces
+// the fault, even if the error is nil, use Maybe to pass nil through.
 //
 //	if err := doSomething(); err != nil {
 //		return nil, errSome.With(err)
 //	}
 func (e Type) With(err error, args ...any) error {
-	var (
-		name string
-		line int
-	)
-
-	if pc, _, ln, ok := runtime.Caller(1); ok {
-		name = runtime.FuncForPC(pc).Name()
-		line = ln
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// Deprecated: Use With
+func (e Type) New(err error, args ...any) error {
+	return e.With(err, args...)
+}
+
+// Maybe wraps error into the context if the error is not nil, it returns nil
+// otherwise. It enables one-line propagation of errors.
+//
+//	return errSome.Maybe(doSomething())
+func (e Type) Maybe(err error, args ...any) error {
+	if err == nil {
+		return nil
 	}
 
-	msg := string(e)
-	if len(args) > 0 {
-		msg = fmt.Sprintf(msg, args...)
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// WithAll wraps multiple errors into the context, e.g. outcomes of parallel
+// workers. errors.Is and errors.As match any of them. Nil errors are skipped.
+//
+//	if err := errSome.WithAll(err1, err2, err3); err != nil {
+//		...
+//	}
+func (e Type) WithAll(errs ...error) error {
+	return withCaller(0, newErrType(e, joinErrs(errs), nil))
+}
+
+// WrapOnce wraps error into the context unless the error already carries
+// the context, keeping chains concise (no `a: a: cause`).
+//
+//	return errSome.WrapOnce(err)
+func (e Type) WrapOnce(err error, args ...any) error {
+	if carries(err, e) {
+		return err
 	}
 
-	return fmt.Errorf("[%s %d] "+msg+": %w", name, line, err)
+	return withCaller(0, newErrType(e, err, args))
 }
 
-// Deprecated: Use With
-func (e Type) New(err error, args ...any) error {
-	return e.With(err, args...)
+// WithElapsed wraps error into the context classifying it by the time elapsed
+// for the operation: it is Timeout if the deadline is exceeded, NearTimeout
+// if the elapsed time approaches the deadline (80%), see IsNearTimeout.
+// The error might be nil, e.g. the operation has succeeded close to deadline,
+// Accounting counts such outcomes as slow success rather than failure.
+// It returns nil if the error is nil and the deadline is not approached.
+//
+//	t := time.Now()
+//	err := doSomething()
+//	return errSome.WithElapsed(err, time.Since(t), deadline)
+func (e Type) WithElapsed(err error, elapsed, deadline time.Duration, args ...any) error {
+	err = elapsedOf(err, elapsed, deadline)
+	if err == nil {
+		return nil
+	}
+
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// Must panics with the error wrapped into the context if the error is not nil.
+// Use it for initialization-time code where returning errors is impractical.
+//
+//	errSome.Must(doSomething())
+func (e Type) Must(err error, args ...any) {
+	if err == nil {
+		return
+	}
+
+	panic(withCaller(0, newErrType(e, err, args)))
 }
 
+// Check returns true if the error is wrapped with the context,
+// it is sugar over errors.Is(err, errSome).
+//
+//	if errSome.Check(err) {
+//		...
+//	}
+func (e Type) Check(err error) bool { return errors.Is(err, e) }
+
 func (e Type) Error() string { return string(e) }
+func (e Type) arity() int    { return -1 }
+func (e Type) zero() string  { return string(e) }
+
+// MatchAlso declares foreign errors (e.g. stdlib sentinels) matched by
+// the context. errors.Is(err, errSome) succeeds if the err is wrapped
+// with any context and the error chain contains one of declared errors.
+//
+//	var errSome = errors.Type("permission denied").MatchAlso(os.ErrPermission)
+func (e Type) MatchAlso(errs ...error) Type {
+	matchAlso(e, errs)
+	return e
+}
 
 // Fast creates a basic context for the error but skips usage of runtime package.
 //
@@ -71,12 +145,7 @@ type Fast string
 //		return nil, errSome.With(err)
 //	}
 func (e Fast) With(err error, args ...any) error {
-	msg := string(e)
-	if len(args) > 0 {
-		msg = fmt.Sprintf(msg, args...)
-	}
-
-	return fmt.Errorf(msg+": %w", err)
+	return seal(newErrType(e, err, args))
 }
 
 // Deprecated: Use With
@@ -84,126 +153,116 @@ func (e Fast) New(err error, args ...any) error {
 	return e.With(err, args...)
 }
 
-func (e Fast) Error() string { return string(e) }
-
-// Safe1 creates an error context with 1 argument
-//
-//	const errSome = errors.Safe1[string]("something is failed %s")
-type Safe1[A any] string
-
-// With wraps error into the context.
-// The function expands the context with arguments.
-//
-//	if err := doSomething(); err != nil {
-//		return nil, errSome.With(err, "foo")
-//	}
-func (safe Safe1[A]) With(err error, a A) error {
-	var (
-		name string
-		line int
-	)
-
-	if pc, _, ln, ok := runtime.Caller(1); ok {
-		name = runtime.FuncForPC(pc).Name()
-		line = ln
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (e Fast) Maybe(err error, args ...any) error {
+	if err == nil {
+		return nil
 	}
 
-	return fmt.Errorf("[%s %d] "+string(safe)+": %w", name, line, a, err)
+	return e.With(err, args...)
 }
 
-// Deprecated: Use With
-func (safe Safe1[A]) New(err error, a A) error {
-	return safe.With(err, a)
+// WithAll wraps multiple errors into the context, see Type.WithAll
+func (e Fast) WithAll(errs ...error) error {
+	return seal(newErrType(e, joinErrs(errs), nil))
 }
 
-// Safe2 creates an error context with 2 argument
-type Safe2[A, B any] string
-
-// With wraps error into the context.
-func (safe Safe2[A, B]) With(err error, a A, b B) error {
-	var (
-		name string
-		line int
-	)
-
-	if pc, _, ln, ok := runtime.Caller(1); ok {
-		name = runtime.FuncForPC(pc).Name()
-		line = ln
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (e Fast) WrapOnce(err error, args ...any) error {
+	if carries(err, e) {
+		return err
 	}
 
-	return fmt.Errorf("[%s %d] "+string(safe)+": %w", name, line, a, b, err)
+	return e.With(err, args...)
 }
 
-// Deprecated: Use With
-func (safe Safe2[A, B]) New(err error, a A, b B) error {
-	return safe.With(err, a, b)
+// Must panics with the error wrapped into the context, see Type.Must
+func (e Fast) Must(err error, args ...any) {
+	if err == nil {
+		return
+	}
+
+	panic(e.With(err, args...))
 }
 
-// Safe3 creates an error context with 3 argument
-type Safe3[A, B, C any] string
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (e Fast) Check(err error) bool { return errors.Is(err, e) }
 
-// With wraps error into the context.
-func (safe Safe3[A, B, C]) With(err error, a A, b B, c C) error {
-	var (
-		name string
-		line int
-	)
-
-	if pc, _, ln, ok := runtime.Caller(1); ok {
-		name = runtime.FuncForPC(pc).Name()
-		line = ln
-	}
+func (e Fast) Error() string { return string(e) }
+func (e Fast) arity() int    { return -1 }
+func (e Fast) zero() string  { return string(e) }
 
-	return fmt.Errorf("[%s %d] "+string(safe)+": %w", name, line, a, b, c, err)
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (e Fast) MatchAlso(errs ...error) Fast {
+	matchAlso(e, errs)
+	return e
 }
 
-// Deprecated: Use With
-func (safe Safe3[A, B, C]) New(err error, a A, b B, c C) error {
-	return safe.With(err, a, b, c)
-}
+// Deep creates a context for the error that captures the full call stack.
+// The stack is resolved lazily when it is requested, see StackOf.
+//
+//	const errSome = errors.Deep("something is failed")
+type Deep string
 
-// Safe4 creates an error context with 4 argument
-type Safe4[A, B, C, D any] string
+// maximum depth of captured call stack
+const maxStackDepth = 32
 
 // With wraps error into the context.
-func (safe Safe4[A, B, C, D]) With(err error, a A, b B, c C, d D) error {
-	var (
-		name string
-		line int
-	)
-
-	if pc, _, ln, ok := runtime.Caller(1); ok {
-		name = runtime.FuncForPC(pc).Name()
-		line = ln
+// The function expands the context with arguments.
+//
+//	if err := doSomething(); err != nil {
+//		return nil, errSome.With(err)
+//	}
+func (e Deep) With(err error, args ...any) error {
+	return e.wrap(err, args)
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (e Deep) Maybe(err error, args ...any) error {
+	if err == nil {
+		return nil
 	}
 
-	return fmt.Errorf("[%s %d] "+string(safe)+": %w", name, line, a, b, c, d, err)
+	return e.wrap(err, args)
 }
 
-// Deprecated: Use With
-func (safe Safe4[A, B, C, D]) New(err error, a A, b B, c C, d D) error {
-	return safe.With(err, a, b, c, d)
+// WithAll wraps multiple errors into the context, see Type.WithAll
+func (e Deep) WithAll(errs ...error) error {
+	return e.wrap(joinErrs(errs), nil)
 }
 
-// Safe5 creates an error context with 5 argument
-type Safe5[A, B, C, D, E any] string
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (e Deep) WrapOnce(err error, args ...any) error {
+	if carries(err, e) {
+		return err
+	}
 
-// With wraps error into the context.
-func (safe Safe5[A, B, C, D, E]) With(err error, a A, b B, c C, d D, e E) error {
-	var (
-		name string
-		line int
-	)
-
-	if pc, _, ln, ok := runtime.Caller(1); ok {
-		name = runtime.FuncForPC(pc).Name()
-		line = ln
+	return e.wrap(err, args)
+}
+
+// Must panics with the error wrapped into the context, see Type.Must
+func (e Deep) Must(err error, args ...any) {
+	if err == nil {
+		return
 	}
 
-	return fmt.Errorf("[%s %d] "+string(safe)+": %w", name, line, a, b, c, d, e, err)
+	panic(e.wrap(err, args))
 }
 
-// Deprecated: Use With
-func (safe Safe5[A, B, C, D, E]) New(err error, a A, b B, c C, d D, e E) error {
-	return safe.With(err, a, b, c, d, e)
+// wrap is called by With, Maybe, WithAll and Must, the stack starts at their caller
+func (e Deep) wrap(err error, args []any) error {
+	return withStack(1, newErrType(e, err, args))
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (e Deep) Check(err error) bool { return errors.Is(err, e) }
+
+func (e Deep) Error() string { return string(e) }
+func (e Deep) arity() int    { return -1 }
+func (e Deep) zero() string  { return string(e) }
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (e Deep) MatchAlso(errs ...error) Deep {
+	matchAlso(e, errs)
+	return e
 }
@@ -18,13 +18,13 @@ import (
 func TestType(t *testing.T) {
 	const errA = errors.Type("a")
 
-	if errA.With(err).Error() != "[github.com/fogfish/faults_test.TestType 21] a: just error" {
+	if errA.With(err).Error() != at("TestType", 21)+"a: just error" {
 		t.Errorf("failed: %s", errA.With(err))
 	}
 
 	const errB = errors.Type("b %s")
 
-	if errB.With(err, "b").Error() != "[github.com/fogfish/faults_test.TestType 27] b b: just error" {
+	if errB.With(err, "b").Error() != at("TestType", 27)+"b b: just error" {
 		t.Errorf("failed: %s", errB.With(err, "b"))
 	}
 }
@@ -46,35 +46,85 @@ func TestFast(t *testing.T) {
 func TestSafe(t *testing.T) {
 	const errA = errors.Safe1[string]("a %s")
 
-	if errA.With(err, "a").Error() != "[github.com/fogfish/faults_test.TestSafe 49] a a: just error" {
+	if errA.With(err, "a").Error() != at("TestSafe", 49)+"a a: just error" {
 		t.Errorf("failed: %s", errA.With(err, "a"))
 	}
 
 	const errB = errors.Safe2[string, string]("a %s %s")
 
-	if errB.With(err, "a", "b").Error() != "[github.com/fogfish/faults_test.TestSafe 55] a a b: just error" {
+	if errB.With(err, "a", "b").Error() != at("TestSafe", 55)+"a a b: just error" {
 		t.Errorf("failed: %s", errB.With(err, "a", "b"))
 	}
 
 	const errC = errors.Safe3[string, string, string]("a %s %s %s")
 
-	if errC.With(err, "a", "b", "c").Error() != "[github.com/fogfish/faults_test.TestSafe 61] a a b c: just error" {
+	if errC.With(err, "a", "b", "c").Error() != at("TestSafe", 61)+"a a b c: just error" {
 		t.Errorf("failed: %s", errC.With(err, "a", "b", "c"))
 	}
 
 	const errD = errors.Safe4[string, string, string, string]("a %s %s %s %s")
 
-	if errD.With(err, "a", "b", "c", "d").Error() != "[github.com/fogfish/faults_test.TestSafe 67] a a b c d: just error" {
+	if errD.With(err, "a", "b", "c", "d").Error() != at("TestSafe", 67)+"a a b c d: just error" {
 		t.Errorf("failed: %s", errD.With(err, "a", "b", "c", "d"))
 	}
 
 	const errE = errors.Safe5[string, string, string, string, string]("a %s %s %s %s %s")
 
-	if errE.With(err, "a", "b", "c", "d", "e").Error() != "[github.com/fogfish/faults_test.TestSafe 73] a a b c d e: just error" {
+	if errE.With(err, "a", "b", "c", "d", "e").Error() != at("TestSafe", 73)+"a a b c d e: just error" {
 		t.Errorf("failed: %s", errE.With(err, "a", "b", "c", "d", "e"))
 	}
+
+	const err6 = errors.Safe6[string, string, string, string, string, string]("a %s %s %s %s %s %s")
+
+	if err6.With(err, "a", "b", "c", "d", "e", "f").Error() != at("TestSafe", 79)+"a a b c d e f: just error" {
+		t.Errorf("failed: %s", err6.With(err, "a", "b", "c", "d", "e", "f"))
+	}
+
+	const err7 = errors.Safe7[string, string, string, string, string, string, string]("a %s %s %s %s %s %s %s")
+
+	if err7.With(err, "a", "b", "c", "d", "e", "f", "g").Error() != at("TestSafe", 85)+"a a b c d e f g: just error" {
+		t.Errorf("failed: %s", err7.With(err, "a", "b", "c", "d", "e", "f", "g"))
+	}
+
+	const err8 = errors.Safe8[string, string, string, string, string, string, string, string]("a %s %s %s %s %s %s %s %s")
+
+	if err8.With(err, "a", "b", "c", "d", "e", "f", "g", "h").Error() != at("TestSafe", 91)+"a a b c d e f g h: just error" {
+		t.Errorf("failed: %s", err8.With(err, "a", "b", "c", "d", "e", "f", "g", "h"))
+	}
+
+	const err9 = errors.Safe9[string, string, string, string, string, string, string, string, string]("a %s %s %s %s %s %s %s %s %s")
+
+	if err9.With(err, "a", "b", "c", "d", "e", "f", "g", "h", "i").Error() != at("TestSafe", 97)+"a a b c d e f g h i: just error" {
+		t.Errorf("failed: %s", err9.With(err, "a", "b", "c", "d", "e", "f", "g", "h", "i"))
+	}
+
+	const err10 = errors.Safe10[string, string, string, string, string, string, string, string, string, string]("a %s %s %s %s %s %s %s %s %s %s")
+
+	if err10.With(err, "a", "b", "c", "d", "e", "f", "g", "h", "i", "j").Error() != at("TestSafe", 103)+"a a b c d e f g h i j: just error" {
+		t.Errorf("failed: %s", err10.With(err, "a", "b", "c", "d", "e", "f", "g", "h", "i", "j"))
+	}
+}
+
+func TestDeep(t *testing.T) {
+	const errA = errors.Deep("a %s")
+
+	e := deepNested(errA)
+	if e.Error() != at("deepNested", 126)+"a a: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	stack := errors.StackOf(fmt.Errorf("b: %w", e))
+	if withCallers && (len(stack) < 2 || stack[0].Function != "github.com/fogfish/faults_test.deepNested" || stack[1].Function != "github.com/fogfish/faults_test.TestDeep") {
+		t.Errorf("failed: %v", stack)
+	}
+
+	if errors.StackOf(errors.Type("b").With(err)) != nil {
+		t.Errorf("failed: stack of type")
+	}
 }
 
+func deepNested(errA errors.Deep) error { return errA.With(err, "a") }
+
 // ------------------------------------------------------------------------------
 //
 // # Benchmark
@@ -89,12 +139,14 @@ const (
 	errFast = errors.Fast("error fast")
 	errType = errors.Type("error type")
 	errSafe = errors.Safe1[string]("error %s")
+	errDeep = errors.Deep("error deep")
 )
 
 func failStdr() error { return fmt.Errorf("error type: %w", err) }
 func failFast() error { return errFast.With(err) }
 func failType() error { return errType.With(err) }
 func failSafe() error { return errSafe.With(err, "safe") }
+func failDeep() error { return errDeep.With(err) }
 
 func BenchmarkStd(b *testing.B) {
 	var err error
@@ -135,3 +187,13 @@ func BenchmarkSafe(b *testing.B) {
 
 	glo = err
 }
+
+func BenchmarkDeep(b *testing.B) {
+	var err error
+
+	for n := 0; n < b.N; n++ {
+		err = failDeep()
+	}
+
+	glo = err
+}
@@ -0,0 +1,36 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+// Expectation declares faults expected by the route or call (e.g. NotFound on
+// GET-by-id). Marked faults are of SeverityDebug and Accounting excludes
+// them from the error rate.
+//
+//	var getByID = faults.Expectation{faults.NotFoundP()}
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		return getByID.Mark(errSome.With(err))
+//	}
+type Expectation []Predicate
+
+// Mark annotates the error as expected if any of predicates fires, the error
+// is returned as-is otherwise, see ErrExpected.
+func (x Expectation) Mark(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, p := range x {
+		if reason, ok := p(err); ok {
+			return ErrExpected(err, reason)
+		}
+	}
+
+	return err
+}
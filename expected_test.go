@@ -0,0 +1,65 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"log/slog"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestExpectation(t *testing.T) {
+	const errA = errors.Type("a")
+
+	getByID := errors.Expectation{errors.NotFoundP()}
+
+	e := getByID.Mark(errA.With(errors.ErrNotFound(err, "k")))
+	if !errors.IsExpected(e) || errors.SeverityOf(e).Level() != slog.LevelDebug {
+		t.Errorf("failed: expected %v", e)
+	}
+
+	if !stderrors.Is(e, errA) || errors.ClassOf(e) != errors.ClassNotFound || !errors.IsNotFound(e, "k") {
+		t.Errorf("failed: expected changes error %v", e)
+	}
+
+	var x errors.ExpectedError
+	if !stderrors.As(e, &x) || x.Expected() != "not_found" {
+		t.Errorf("failed: reason %v", x.Expected())
+	}
+
+	e = getByID.Mark(errA.With(errors.ErrConflict(err)))
+	if errors.IsExpected(e) || errors.SeverityOf(e) != errors.SeverityInfo {
+		t.Errorf("failed: unexpected %v", e)
+	}
+
+	if getByID.Mark(nil) != nil || errors.ErrExpected(nil, "k") != nil {
+		t.Errorf("failed: nil")
+	}
+}
+
+func TestAccountingExpected(t *testing.T) {
+	var slo errors.Accounting
+
+	getByID := errors.Expectation{errors.NotFoundP()}
+
+	slo.Add(nil)
+	slo.Add(getByID.Mark(errors.ErrNotFound(err, "k")))
+	slo.Add(getByID.Mark(errors.ErrDown(err)))
+
+	r := slo.Reset()
+	if r.Total != 3 || r.Expected != 1 || r.User != 0 || r.System != 1 || r.Classes[errors.ClassNotFound] != 0 {
+		t.Errorf("failed: %+v", r)
+	}
+
+	if r := slo.Report(); r.Expected != 0 {
+		t.Errorf("failed: %+v", r)
+	}
+}
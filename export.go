@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// taxonomy of faults shared with other languages
+type taxonomy struct {
+	Templates []string `json:"templates"`
+	Arity     []int    `json:"-"`
+	Codes     []string `json:"-"`
+	Classes   []Class  `json:"classes"`
+	Statuses  []int    `json:"-"`
+}
+
+func (c Catalog) taxonomy() taxonomy {
+	t := taxonomy{Classes: Classes}
+	for _, class := range t.Classes {
+		t.Statuses = append(t.Statuses, mappingOf(class).HTTP)
+	}
+
+	for _, err := range c {
+		arity := -1
+		if decl, ok := err.(declaration); ok {
+			arity = decl.arity()
+		}
+
+		code := ""
+		if c, ok := err.(interface{ ErrCode() string }); ok {
+			code = c.ErrCode()
+		}
+
+		t.Templates = append(t.Templates, err.Error())
+		t.Arity = append(t.Arity, arity)
+		t.Codes = append(t.Codes, code)
+	}
+	return t
+}
+
+// WriteJSONSchema exports the catalog as JSON schema of the error object
+// `{"class": "...", "code": "...", "template": "...", "message": "...", "status": 404}`
+// produced by faults based backends. The HTTP status is constrained by
+// the class according to mappings, see MappingOf.
+//
+//	catalog.WriteJSONSchema(os.Stdout)
+func (c Catalog) WriteJSONSchema(w io.Writer) error {
+	t := c.taxonomy()
+
+	properties := map[string]any{
+		"class":    map[string]any{"enum": t.Classes},
+		"template": map[string]any{"enum": t.Templates},
+		"message":  map[string]any{"type": "string"},
+		"status":   map[string]any{"type": "integer"},
+	}
+
+	statuses := make([]any, len(t.Classes))
+	for i, class := range t.Classes {
+		statuses[i] = map[string]any{
+			"if":   map[string]any{"properties": map[string]any{"class": map[string]any{"const": class}}, "required": []string{"class"}},
+			"then": map[string]any{"properties": map[string]any{"status": map[string]any{"const": t.Statuses[i]}}},
+		}
+	}
+
+	var codes []string
+	for _, code := range t.Codes {
+		if code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) > 0 {
+		properties["code"] = map[string]any{"enum": codes}
+	}
+
+	schema := map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"title":      "faults",
+		"type":       "object",
+		"properties": properties,
+		"required":   []string{"message"},
+		"allOf":      statuses,
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schema)
+}
+
+// WriteTypeScript exports the catalog as TypeScript constants, front-end
+// branches on the error using them. HTTP statuses of classes are exported
+// according to mappings, see MappingOf.
+func (c Catalog) WriteTypeScript(w io.Writer) error {
+	t := c.taxonomy()
+
+	if _, err := fmt.Fprint(w, "// Code generated by faults. DO NOT EDIT.\n\n"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, "export const Classes = [\n"); err != nil {
+		return err
+	}
+	for _, class := range t.Classes {
+		if _, err := fmt.Fprintf(w, "  %s,\n", quote(string(class))); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "] as const;\n\nexport type Class = typeof Classes[number];\n\n"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, "export const HTTPStatus: Record<Class, number> = {\n"); err != nil {
+		return err
+	}
+	for i, class := range t.Classes {
+		if _, err := fmt.Fprintf(w, "  %s: %d,\n", quote(string(class)), t.Statuses[i]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "};\n\n"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, "export const Templates = {\n"); err != nil {
+		return err
+	}
+	for i, template := range t.Templates {
+		code := ""
+		if t.Codes[i] != "" {
+			code = ", code: " + quote(t.Codes[i])
+		}
+		if _, err := fmt.Fprintf(w, "  %s: { arity: %d%s },\n", quote(template), t.Arity[i], code); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "} as const;\n\nexport type Template = keyof typeof Templates;\n"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// quote string as JSON, which is valid literal in TypeScript
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,96 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestWriteJSONSchema(t *testing.T) {
+	catalog := errors.Catalog{errors.Type("a"), errors.Safe1[int]("b %d")}
+
+	var buf bytes.Buffer
+	if err := catalog.WriteJSONSchema(&buf); err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	var schema struct {
+		Properties struct {
+			Template struct {
+				Enum []string `json:"enum"`
+			} `json:"template"`
+			Class struct {
+				Enum []string `json:"enum"`
+			} `json:"class"`
+		} `json:"properties"`
+		AllOf []struct {
+			If struct {
+				Properties struct {
+					Class struct {
+						Const string `json:"const"`
+					} `json:"class"`
+				} `json:"properties"`
+			} `json:"if"`
+			Then struct {
+				Properties struct {
+					Status struct {
+						Const int `json:"const"`
+					} `json:"status"`
+				} `json:"properties"`
+			} `json:"then"`
+		} `json:"allOf"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	if tpl := schema.Properties.Template.Enum; len(tpl) != 2 || tpl[0] != "a" || tpl[1] != "b %d" {
+		t.Errorf("failed: %v", tpl)
+	}
+
+	if cls := schema.Properties.Class.Enum; len(cls) != len(errors.Classes) {
+		t.Errorf("failed: %v", cls)
+	}
+
+	statuses := map[string]int{}
+	for _, x := range schema.AllOf {
+		statuses[x.If.Properties.Class.Const] = x.Then.Properties.Status.Const
+	}
+	if len(statuses) != len(errors.Classes) || statuses["not_found"] != 404 || statuses["down"] != 503 {
+		t.Errorf("failed: %v", statuses)
+	}
+}
+
+func TestWriteTypeScript(t *testing.T) {
+	catalog := errors.Catalog{errors.Type("a \"x\""), errors.Safe1[int]("b %d")}
+
+	var buf bytes.Buffer
+	if err := catalog.WriteTypeScript(&buf); err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	ts := buf.String()
+	for _, expect := range []string{
+		`"not_found",`,
+		`"a \"x\"": { arity: -1 },`,
+		`"b %d": { arity: 1 },`,
+		`export const HTTPStatus: Record<Class, number> = {`,
+		`"not_found": 404,`,
+		`export type Template = keyof typeof Templates;`,
+	} {
+		if !strings.Contains(ts, expect) {
+			t.Errorf("failed: %s not found in\n%s", expect, ts)
+		}
+	}
+}
@@ -0,0 +1,749 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// Code generated by internal/gensafe. DO NOT EDIT.
+
+package faults
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FastSafe1 creates an error context with 1 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+//
+//	const errSome = errors.FastSafe1[string]("something is failed %s")
+type FastSafe1[A any] string
+
+// With wraps error into the context.
+// The function expands the context with arguments.
+//
+//	if err := doSomething(); err != nil {
+//		return nil, errSome.With(err, "foo")
+//	}
+func (safe FastSafe1[A]) With(err error, a A) error {
+	return seal(newFault(safe, err, []any{a}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe1[A]) Maybe(err error, a A) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe1[A]) WrapOnce(err error, a A) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+//
+//	if a, ok := errSome.Values(err); ok {
+//		...
+//	}
+func (safe FastSafe1[A]) Values(err error) (a A, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 1 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe1[A]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe1[A]) Error() string { return string(safe) }
+func (safe FastSafe1[A]) arity() int    { return 1 }
+
+func (safe FastSafe1[A]) zero() string {
+	var (
+		a A
+	)
+	return fmt.Sprintf(string(safe), a)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe1[A]) MatchAlso(errs ...error) FastSafe1[A] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe2 creates an error context with 2 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe2[A, B any] string
+
+// With wraps error into the context.
+func (safe FastSafe2[A, B]) With(err error, a A, b B) error {
+	return seal(newFault(safe, err, []any{a, b}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe2[A, B]) Maybe(err error, a A, b B) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe2[A, B]) WrapOnce(err error, a A, b B) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe2[A, B]) Values(err error) (a A, b B, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 2 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe2[A, B]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe2[A, B]) Error() string { return string(safe) }
+func (safe FastSafe2[A, B]) arity() int    { return 2 }
+
+func (safe FastSafe2[A, B]) zero() string {
+	var (
+		a A
+		b B
+	)
+	return fmt.Sprintf(string(safe), a, b)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe2[A, B]) MatchAlso(errs ...error) FastSafe2[A, B] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe3 creates an error context with 3 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe3[A, B, C any] string
+
+// With wraps error into the context.
+func (safe FastSafe3[A, B, C]) With(err error, a A, b B, c C) error {
+	return seal(newFault(safe, err, []any{a, b, c}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe3[A, B, C]) Maybe(err error, a A, b B, c C) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe3[A, B, C]) WrapOnce(err error, a A, b B, c C) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe3[A, B, C]) Values(err error) (a A, b B, c C, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 3 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe3[A, B, C]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe3[A, B, C]) Error() string { return string(safe) }
+func (safe FastSafe3[A, B, C]) arity() int    { return 3 }
+
+func (safe FastSafe3[A, B, C]) zero() string {
+	var (
+		a A
+		b B
+		c C
+	)
+	return fmt.Sprintf(string(safe), a, b, c)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe3[A, B, C]) MatchAlso(errs ...error) FastSafe3[A, B, C] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe4 creates an error context with 4 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe4[A, B, C, D any] string
+
+// With wraps error into the context.
+func (safe FastSafe4[A, B, C, D]) With(err error, a A, b B, c C, d D) error {
+	return seal(newFault(safe, err, []any{a, b, c, d}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe4[A, B, C, D]) Maybe(err error, a A, b B, c C, d D) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe4[A, B, C, D]) WrapOnce(err error, a A, b B, c C, d D) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe4[A, B, C, D]) Values(err error) (a A, b B, c C, d D, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 4 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		d, _ = x.args[3].(D)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe4[A, B, C, D]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe4[A, B, C, D]) Error() string { return string(safe) }
+func (safe FastSafe4[A, B, C, D]) arity() int    { return 4 }
+
+func (safe FastSafe4[A, B, C, D]) zero() string {
+	var (
+		a A
+		b B
+		c C
+		d D
+	)
+	return fmt.Sprintf(string(safe), a, b, c, d)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe4[A, B, C, D]) MatchAlso(errs ...error) FastSafe4[A, B, C, D] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe5 creates an error context with 5 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe5[A, B, C, D, E any] string
+
+// With wraps error into the context.
+func (safe FastSafe5[A, B, C, D, E]) With(err error, a A, b B, c C, d D, e E) error {
+	return seal(newFault(safe, err, []any{a, b, c, d, e}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe5[A, B, C, D, E]) Maybe(err error, a A, b B, c C, d D, e E) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe5[A, B, C, D, E]) WrapOnce(err error, a A, b B, c C, d D, e E) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe5[A, B, C, D, E]) Values(err error) (a A, b B, c C, d D, e E, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 5 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		d, _ = x.args[3].(D)
+		e, _ = x.args[4].(E)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe5[A, B, C, D, E]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe5[A, B, C, D, E]) Error() string { return string(safe) }
+func (safe FastSafe5[A, B, C, D, E]) arity() int    { return 5 }
+
+func (safe FastSafe5[A, B, C, D, E]) zero() string {
+	var (
+		a A
+		b B
+		c C
+		d D
+		e E
+	)
+	return fmt.Sprintf(string(safe), a, b, c, d, e)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe5[A, B, C, D, E]) MatchAlso(errs ...error) FastSafe5[A, B, C, D, E] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe6 creates an error context with 6 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe6[A, B, C, D, E, F any] string
+
+// With wraps error into the context.
+func (safe FastSafe6[A, B, C, D, E, F]) With(err error, a A, b B, c C, d D, e E, f F) error {
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe6[A, B, C, D, E, F]) Maybe(err error, a A, b B, c C, d D, e E, f F) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe6[A, B, C, D, E, F]) WrapOnce(err error, a A, b B, c C, d D, e E, f F) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe6[A, B, C, D, E, F]) Values(err error) (a A, b B, c C, d D, e E, f F, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 6 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		d, _ = x.args[3].(D)
+		e, _ = x.args[4].(E)
+		f, _ = x.args[5].(F)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe6[A, B, C, D, E, F]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe6[A, B, C, D, E, F]) Error() string { return string(safe) }
+func (safe FastSafe6[A, B, C, D, E, F]) arity() int    { return 6 }
+
+func (safe FastSafe6[A, B, C, D, E, F]) zero() string {
+	var (
+		a A
+		b B
+		c C
+		d D
+		e E
+		f F
+	)
+	return fmt.Sprintf(string(safe), a, b, c, d, e, f)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe6[A, B, C, D, E, F]) MatchAlso(errs ...error) FastSafe6[A, B, C, D, E, F] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe7 creates an error context with 7 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe7[A, B, C, D, E, F, G any] string
+
+// With wraps error into the context.
+func (safe FastSafe7[A, B, C, D, E, F, G]) With(err error, a A, b B, c C, d D, e E, f F, g G) error {
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe7[A, B, C, D, E, F, G]) Maybe(err error, a A, b B, c C, d D, e E, f F, g G) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe7[A, B, C, D, E, F, G]) WrapOnce(err error, a A, b B, c C, d D, e E, f F, g G) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe7[A, B, C, D, E, F, G]) Values(err error) (a A, b B, c C, d D, e E, f F, g G, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 7 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		d, _ = x.args[3].(D)
+		e, _ = x.args[4].(E)
+		f, _ = x.args[5].(F)
+		g, _ = x.args[6].(G)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe7[A, B, C, D, E, F, G]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe7[A, B, C, D, E, F, G]) Error() string { return string(safe) }
+func (safe FastSafe7[A, B, C, D, E, F, G]) arity() int    { return 7 }
+
+func (safe FastSafe7[A, B, C, D, E, F, G]) zero() string {
+	var (
+		a A
+		b B
+		c C
+		d D
+		e E
+		f F
+		g G
+	)
+	return fmt.Sprintf(string(safe), a, b, c, d, e, f, g)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe7[A, B, C, D, E, F, G]) MatchAlso(errs ...error) FastSafe7[A, B, C, D, E, F, G] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe8 creates an error context with 8 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe8[A, B, C, D, E, F, G, H any] string
+
+// With wraps error into the context.
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) With(err error, a A, b B, c C, d D, e E, f F, g G, h H) error {
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) Maybe(err error, a A, b B, c C, d D, e E, f F, g G, h H) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) WrapOnce(err error, a A, b B, c C, d D, e E, f F, g G, h H) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) Values(err error) (a A, b B, c C, d D, e E, f F, g G, h H, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 8 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		d, _ = x.args[3].(D)
+		e, _ = x.args[4].(E)
+		f, _ = x.args[5].(F)
+		g, _ = x.args[6].(G)
+		h, _ = x.args[7].(H)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) Error() string { return string(safe) }
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) arity() int    { return 8 }
+
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) zero() string {
+	var (
+		a A
+		b B
+		c C
+		d D
+		e E
+		f F
+		g G
+		h H
+	)
+	return fmt.Sprintf(string(safe), a, b, c, d, e, f, g, h)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe8[A, B, C, D, E, F, G, H]) MatchAlso(errs ...error) FastSafe8[A, B, C, D, E, F, G, H] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe9 creates an error context with 9 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe9[A, B, C, D, E, F, G, H, I any] string
+
+// With wraps error into the context.
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) With(err error, a A, b B, c C, d D, e E, f F, g G, h H, i I) error {
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h, i}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) Maybe(err error, a A, b B, c C, d D, e E, f F, g G, h H, i I) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h, i}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) WrapOnce(err error, a A, b B, c C, d D, e E, f F, g G, h H, i I) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h, i}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) Values(err error) (a A, b B, c C, d D, e E, f F, g G, h H, i I, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 9 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		d, _ = x.args[3].(D)
+		e, _ = x.args[4].(E)
+		f, _ = x.args[5].(F)
+		g, _ = x.args[6].(G)
+		h, _ = x.args[7].(H)
+		i, _ = x.args[8].(I)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) Error() string { return string(safe) }
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) arity() int    { return 9 }
+
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) zero() string {
+	var (
+		a A
+		b B
+		c C
+		d D
+		e E
+		f F
+		g G
+		h H
+		i I
+	)
+	return fmt.Sprintf(string(safe), a, b, c, d, e, f, g, h, i)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe9[A, B, C, D, E, F, G, H, I]) MatchAlso(errs ...error) FastSafe9[A, B, C, D, E, F, G, H, I] {
+	matchAlso(safe, errs)
+	return safe
+}
+
+// FastSafe10 creates an error context with 10 argument, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+type FastSafe10[A, B, C, D, E, F, G, H, I, J any] string
+
+// With wraps error into the context.
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) With(err error, a A, b B, c C, d D, e E, f F, g G, h H, i I, j J) error {
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h, i, j}))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) Maybe(err error, a A, b B, c C, d D, e E, f F, g G, h H, i I, j J) error {
+	if err == nil {
+		return nil
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h, i, j}))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) WrapOnce(err error, a A, b B, c C, d D, e E, f F, g G, h H, i I, j J) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return seal(newFault(safe, err, []any{a, b, c, d, e, f, g, h, i, j}))
+}
+
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) Values(err error) (a A, b B, c C, d D, e E, f F, g G, h H, i I, j J, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != 10 {
+			return true
+		}
+
+		a, _ = x.args[0].(A)
+		b, _ = x.args[1].(B)
+		c, _ = x.args[2].(C)
+		d, _ = x.args[3].(D)
+		e, _ = x.args[4].(E)
+		f, _ = x.args[5].(F)
+		g, _ = x.args[6].(G)
+		h, _ = x.args[7].(H)
+		i, _ = x.args[8].(I)
+		j, _ = x.args[9].(J)
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) Check(err error) bool {
+	return errors.Is(err, safe)
+}
+
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) Error() string { return string(safe) }
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) arity() int    { return 10 }
+
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) zero() string {
+	var (
+		a A
+		b B
+		c C
+		d D
+		e E
+		f F
+		g G
+		h H
+		i I
+		j J
+	)
+	return fmt.Sprintf(string(safe), a, b, c, d, e, f, g, h, i, j)
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe FastSafe10[A, B, C, D, E, F, G, H, I, J]) MatchAlso(errs ...error) FastSafe10[A, B, C, D, E, F, G, H, I, J] {
+	matchAlso(safe, errs)
+	return safe
+}
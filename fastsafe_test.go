@@ -0,0 +1,60 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestFastSafe(t *testing.T) {
+	const (
+		errA = errors.FastSafe1[int]("a %d")
+		errB = errors.FastSafe2[int, string]("b %d %s")
+		errJ = errors.FastSafe10[int, int, int, int, int, int, int, int, int, int]("j %d %d %d %d %d %d %d %d %d %d")
+	)
+
+	e := errA.With(errB.With(errJ.With(err, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10), 2, "b"), 1)
+
+	if e.Error() != "a 1: b 2 b: j 1 2 3 4 5 6 7 8 9 10: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if !errA.Check(e) || !errB.Check(e) || !errJ.Check(e) || !stderrors.Is(e, err) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if stderrors.Is(e, errors.Safe1[int]("a %d")) {
+		t.Errorf("failed: FastSafe1 matches Safe1")
+	}
+
+	if a, b, ok := errB.Values(e); !ok || a != 2 || b != "b" {
+		t.Errorf("failed: %v %v", a, b)
+	}
+
+	if errA.Maybe(nil, 1) != nil {
+		t.Errorf("failed: maybe")
+	}
+
+	errors.Catalog{errA, errB, errJ}.SelfTest(t)
+}
+
+func BenchmarkFastSafe(b *testing.B) {
+	const errA = errors.FastSafe1[int]("a %d")
+
+	var e error
+
+	for n := 0; n < b.N; n++ {
+		e = errA.With(err, n)
+	}
+
+	glo = e
+}
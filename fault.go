@@ -0,0 +1,609 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Fault is the error produced by the context. The interface is a stable
+// contract for building custom renderers and transports of errors.
+//
+//	var fault faults.Fault
+//	if errors.As(err, &fault) {
+//		fault.Message()
+//	}
+type Fault interface {
+	error
+
+	// Message of the context, template rendered with arguments
+	Message() string
+
+	// Args used to render the message
+	Args() []any
+
+	// Caller is the function and the line that has produced the fault,
+	// empty if the context does not capture the caller (e.g. Fast).
+	Caller() (string, int)
+
+	// Cause is the original error wrapped by the context
+	Cause() error
+
+	// Class of the fault, see ClassOf
+	Class() Class
+
+	// Code of the fault, empty if the context does not declare it
+	Code() string
+}
+
+// errType is the error produced by the context. It keeps the declaration
+// of the context (head) along with the original error (tail) so that
+// errors.Is matches either of them.
+type errType struct {
+	// program counter of the caller, resolved lazily into location
+	pc  uintptr
+	loc atomic.Pointer[runtime.Frame]
+
+	args   []any
+	fields []Field
+	head   error
+	tail   error
+
+	// call stack captured by Deep context
+	stack []uintptr
+
+	// typed payload attached by Of context
+	payload any
+
+	// creation time, captured if timestamps are enabled
+	at time.Time
+
+	// build of the binary, stamped if builds are enabled
+	build *BuildInfo
+
+	// message and error are rendered lazily, once
+	text atomic.Pointer[string]
+	msg  atomic.Pointer[string]
+
+	// faults are immutable, the inspection is cached until registries change
+	inspection atomic.Pointer[inspected]
+
+	// occurrence id, assigned lazily by Ref
+	occurrence atomic.Uint64
+
+	// guards args from mutation in debug builds
+	guard
+}
+
+func (e *errType) Error() string {
+	e.verify()
+
+	if msg := e.msg.Load(); msg != nil {
+		return *msg
+	}
+
+	var sb strings.Builder
+	c := cfg.Load()
+
+	if frame := e.frame(); frame.Function != "" {
+		if about := c.about(*frame); about != "" {
+			sb.WriteString(about)
+			sb.WriteString(" ")
+		}
+	}
+
+	sb.WriteString(e.Message())
+
+	if e.tail != nil {
+		sb.WriteString(": ")
+		if c.concise {
+			sb.WriteString(concise(e.tail))
+		} else {
+			sb.WriteString(e.tail.Error())
+		}
+	}
+
+	msg := sb.String()
+	e.msg.Store(&msg)
+
+	return msg
+}
+
+// frame resolves the caller lazily, once. Most faults are handled
+// without being printed, the wrap stores the program counter only.
+func (e *errType) frame() *runtime.Frame {
+	if loc := e.loc.Load(); loc != nil {
+		return loc
+	}
+
+	loc := &runtime.Frame{}
+	if e.pc != 0 {
+		*loc, _ = runtime.CallersFrames([]uintptr{e.pc}).Next()
+	}
+	e.loc.Store(loc)
+
+	return loc
+}
+
+// location of the caller, the function and the line
+func (e *errType) location() (string, int) {
+	loc := e.frame()
+	return loc.Function, loc.Line
+}
+
+func (e *errType) Message() string {
+	if text := e.text.Load(); text != nil {
+		return *text
+	}
+
+	var text string
+	if r, ok := e.head.(interface{ render([]any) string }); ok {
+		text = r.render(e.args)
+	} else {
+		text = sprintf(e.head.Error(), e.args)
+	}
+	e.text.Store(&text)
+
+	return text
+}
+
+// Format implements fmt.Formatter. The verb %+v prints the chain, one cause
+// per line, along with callers or call stacks captured by contexts.
+//
+//	fmt.Printf("%+v", err)
+func (e *errType) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		e.formatChain(s)
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// formatChain writes the fault and its causes, foreign errors are terminal
+func (e *errType) formatChain(w io.Writer) {
+	io.WriteString(w, e.Message())
+
+	if frames := e.StackTrace(); len(frames) > 0 {
+		for _, frame := range frames {
+			fmt.Fprintf(w, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	} else if name, line := e.location(); name != "" {
+		fmt.Fprintf(w, "\n\t%s:%d", name, line)
+	}
+
+	causes := []error{e.tail}
+	if list, ok := e.tail.(*errList); ok {
+		causes = list.errs
+	}
+
+	for _, err := range causes {
+		switch x := err.(type) {
+		case nil:
+		case *errType:
+			io.WriteString(w, "\n")
+			x.formatChain(w)
+		default:
+			io.WriteString(w, "\n")
+			io.WriteString(w, x.Error())
+		}
+	}
+}
+
+func (e *errType) Args() []any           { e.verify(); return e.args }
+func (e *errType) Caller() (string, int) { return e.location() }
+func (e *errType) Cause() error          { return e.tail }
+func (e *errType) Class() Class          { return ClassOf(e) }
+
+// Code of the context declared by Code
+func (e *errType) Code() string {
+	if c, ok := e.head.(interface{ ErrCode() string }); ok {
+		return c.ErrCode()
+	}
+	return ""
+}
+
+// StackTrace resolves the call stack captured by the context
+func (e *errType) StackTrace() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	seq := make([]runtime.Frame, 0, len(e.stack))
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		seq = append(seq, frame)
+		if !more {
+			break
+		}
+	}
+
+	return seq
+}
+
+func (e *errType) Unwrap() []error {
+	switch tail := e.tail.(type) {
+	case nil:
+		return []error{e.head}
+	case *errList:
+		return append([]error{e.head}, tail.errs...)
+	default:
+		return []error{e.head, e.tail}
+	}
+}
+
+// errList is the cause of the fault that wraps multiple errors
+type errList struct{ errs []error }
+
+func (e *errList) Unwrap() []error { return e.errs }
+
+func (e *errList) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("[")
+	for i, err := range e.errs {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(err.Error())
+	}
+	sb.WriteString("]")
+
+	return sb.String()
+}
+
+// joinErrs builds cause from non-nil errors
+func joinErrs(errs []error) error {
+	seq := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			seq = append(seq, err)
+		}
+	}
+
+	switch len(seq) {
+	case 0:
+		return nil
+	case 1:
+		return seq[0]
+	default:
+		return &errList{errs: seq}
+	}
+}
+
+// Is matches the target context explicitly, comparing it with the declared
+// head, or if the tail contains any of foreign errors declared by MatchAlso.
+// Heads are comparable, instantiations of generic contexts (e.g. Safe1[int]
+// and Safe1[string]) with the same template are distinct contexts.
+func (e *errType) Is(target error) bool {
+	if e.head == target {
+		return true
+	}
+
+	if e.tail == nil {
+		return false
+	}
+
+	for _, x := range aliasesOf(target) {
+		if errors.Is(e.tail, x) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OneOf returns true if the error matches any of targets using errors.Is.
+//
+//	if faults.OneOf(err, errSomeA, errSomeB, io.EOF) {
+//		...
+//	}
+func OneOf(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// carries returns true if any fault of the chain is produced by the context.
+// Unlike errors.Is, aliases declared by MatchAlso are not considered.
+func carries(err error, head error) bool {
+	found := false
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(*errType); ok && e.head == head {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+// StackOf returns the call stack captured by the first Deep context in
+// the chain.
+//
+//	for _, frame := range faults.StackOf(err) {
+//		fmt.Printf("%s:%d\n", frame.File, frame.Line)
+//	}
+func StackOf(err error) []runtime.Frame {
+	var stack []runtime.Frame
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(*errType); ok && len(e.stack) > 0 {
+			stack = e.StackTrace()
+			return false
+		}
+		return true
+	})
+
+	return stack
+}
+
+// Root returns the deepest cause of the error that is not a fault, the first
+// one wins if branches of the chain are equally deep. It returns nil if the
+// chain consists of faults only.
+//
+//	log.Printf("root cause: %v", faults.Root(err))
+func Root(err error) error {
+	var root error
+	deepest := -1
+
+	Walk(err, func(err error, depth int) bool {
+		switch err.(type) {
+		case *errType, *errList, declaration:
+		default:
+			if depth > deepest {
+				root, deepest = err, depth
+			}
+		}
+		return true
+	})
+
+	return root
+}
+
+// Flatten returns every distinct error of the chain in pre-order, faults
+// along with their causes, e.g. to list all problems of a batch operation.
+// Contexts of faults are not included. It returns nil if the error is nil.
+//
+//	for _, e := range faults.Flatten(err) {
+//		...
+//	}
+func Flatten(err error) []error {
+	var seq []error
+	seen := map[error]struct{}{}
+
+	Walk(err, func(err error, _ int) bool {
+		switch err.(type) {
+		case *errList, declaration:
+			return true
+		}
+
+		if hashable(err) {
+			if _, has := seen[err]; has {
+				return true
+			}
+			seen[err] = struct{}{}
+		}
+
+		seq = append(seq, err)
+		return true
+	})
+
+	return seq
+}
+
+// hashable returns true if the error is safe to use as the map key, structs
+// and arrays might hold non-comparable values in interface fields.
+func hashable(err error) bool {
+	t := reflect.TypeOf(err)
+	return t.Comparable() && t.Kind() != reflect.Struct && t.Kind() != reflect.Array
+}
+
+// newFault allocates the fault, it is taken from the pool if pooling is
+// enabled. The fault is not sealed.
+func newFault(head, tail error, args []any) *errType {
+	e := acquire()
+	e.head = head
+	e.tail = tail
+	e.args = args
+	return e
+}
+
+// newErrType creates the fault of variadic context (Type, Fast, Deep),
+// fields are separated from template arguments. The fault is not sealed.
+func newErrType(head, tail error, args []any) *errType {
+	args, fields := splitFields(args)
+	args, dropped := capArgs(args, maxArgsOf(head))
+	if dropped > 0 {
+		fields = append(fields, Field{Key: "dropped_args", Value: dropped})
+	}
+
+	e := newFault(head, tail, args)
+	e.fields = fields
+	return e
+}
+
+// withCaller is the core of every constructor, it annotates the fault with
+// the caller and seals it. The skip is the number of frames to ascend,
+// 0 identifies the caller of the constructor. Composite constructors
+// increment skip for each own frame so that faults report the user's frame.
+func withCaller(skip int, e *errType) *errType {
+	if captureCallers && !cfg.Load().skipCallers {
+		e.pc = callerPC(skip + 1)
+	}
+	return seal(e)
+}
+
+// withStack annotates the fault with the call stack and seals it, see withCaller.
+func withStack(skip int, e *errType) *errType {
+	if !captureCallers || cfg.Load().skipCallers {
+		return seal(e)
+	}
+
+	var pcs [maxStackDepth]uintptr
+
+	n := runtime.Callers(skip+3, pcs[:])
+	if n > 0 {
+		e.pc = pcs[0]
+	}
+	e.stack = append([]uintptr(nil), pcs[:n]...)
+
+	return seal(e)
+}
+
+// Extend wraps the error into the context annotating it with the caller,
+// so that third-party composite constructors report the user's frame.
+// The skip is the number of frames between the user's code and the function
+// calling Extend, 0 for the constructor called by the user directly.
+//
+//	func ErrNotFound(err error, key string) error {
+//		return faults.Extend(0, errNotFound, err, key)
+//	}
+func Extend(skip int, head, err error, args ...any) error {
+	return withCaller(skip+1, newErrType(head, err, args))
+}
+
+// FaultOption customizes the fault built by NewFault
+type FaultOption func(*faultSpec)
+
+type faultSpec struct {
+	skip     int
+	noCaller bool
+	stack    bool
+}
+
+// CallerSkip is the number of frames between the user's code and
+// the function calling NewFault, see Extend.
+func CallerSkip(n int) FaultOption {
+	return func(s *faultSpec) { s.skip = n }
+}
+
+// NoCaller skips the caller capture, see Fast.
+func NoCaller() FaultOption {
+	return func(s *faultSpec) { s.noCaller = true }
+}
+
+// CaptureStack captures the full call stack, see Deep.
+func CaptureStack() FaultOption {
+	return func(s *faultSpec) { s.stack = true }
+}
+
+// NewFault is the building block of custom composite faults defined outside
+// of the package. Faults are rendered, annotated with callers and unwrapped
+// exactly as ones produced by contexts. The head is the declaration of
+// the context, the cause is the original error.
+//
+//	func ErrNotFound(err error, key string) error {
+//		return faults.NewFault(errNotFound, err, []any{key})
+//	}
+func NewFault(head, cause error, args []any, opts ...FaultOption) error {
+	var spec faultSpec
+	for _, opt := range opts {
+		opt(&spec)
+	}
+
+	e := newErrType(head, cause, args)
+
+	switch {
+	case spec.stack:
+		return withStack(spec.skip+1, e)
+	case spec.noCaller:
+		return seal(e)
+	default:
+		return withCaller(spec.skip+1, e)
+	}
+}
+
+// callerPC returns the program counter of the caller, skip is
+// the number of frames to ascend, 0 identifies the caller of caller.
+func callerPC(skip int) uintptr {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+3, pcs[:]) == 0 {
+		return 0
+	}
+
+	return pcs[0]
+}
+
+//------------------------------------------------------------------------------
+
+type alias struct {
+	head error
+	also []error
+}
+
+// aliases are copied on write, errors are matched without locks
+var (
+	muAliases sync.Mutex
+	aliases   atomic.Pointer[[]alias]
+)
+
+func matchAlso(head error, errs []error) {
+	muAliases.Lock()
+	defer muAliases.Unlock()
+
+	var seq []alias
+	if x := aliases.Load(); x != nil {
+		seq = slices.Clone(*x)
+	}
+
+	i := slices.IndexFunc(seq, func(x alias) bool { return x.head == head })
+	if i == -1 {
+		seq = append(seq, alias{head: head})
+		i = len(seq) - 1
+	}
+
+	also := slices.Clone(seq[i].also)
+	for _, err := range errs {
+		if err != nil && !slices.ContainsFunc(also, func(x error) bool { return sameError(x, err) }) {
+			also = append(also, err)
+		}
+	}
+	seq[i].also = also
+
+	aliases.Store(&seq)
+}
+
+// sameError compares errors, errors of non-hashable types are distinct.
+func sameError(a, b error) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && hashable(a) && a == b
+}
+
+// Declarations are comparable, the comparison with target never panics.
+func aliasesOf(target error) []error {
+	seq := aliases.Load()
+	if seq == nil {
+		return nil
+	}
+
+	for _, x := range *seq {
+		if x.head == target {
+			return x.also
+		}
+	}
+
+	return nil
+}
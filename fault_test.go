@@ -0,0 +1,548 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestIs(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+		errC = errors.Safe1[string]("c %s")
+	)
+
+	for _, e := range []error{errA.With(err), errB.With(err), errC.With(err, "c")} {
+		if !stderrors.Is(e, err) {
+			t.Errorf("failed: %s is not %s", e, err)
+		}
+	}
+
+	if !stderrors.Is(errA.With(err), errA) {
+		t.Errorf("failed: is not errA")
+	}
+
+	if !stderrors.Is(errB.With(err), errB) {
+		t.Errorf("failed: is not errB")
+	}
+
+	if !stderrors.Is(errC.With(err, "c"), errC) {
+		t.Errorf("failed: is not errC")
+	}
+
+	if stderrors.Is(errA.With(err), errB) {
+		t.Errorf("failed: is errB")
+	}
+}
+
+func TestMatchAlso(t *testing.T) {
+	var errA = errors.Type("access denied").MatchAlso(fs.ErrPermission)
+
+	_, cause := os.Open("/.faults-do-not-exist")
+	if stderrors.Is(errors.Fast("other").With(cause), errA) {
+		t.Errorf("failed: not exist is errA")
+	}
+
+	if !stderrors.Is(errors.Fast("other").With(fs.ErrPermission), errA) {
+		t.Errorf("failed: permission is not errA")
+	}
+
+	if !stderrors.Is(errA.With(fmt.Errorf("io: %w", fs.ErrPermission)), errA) {
+		t.Errorf("failed: permission is not errA")
+	}
+
+	if stderrors.Is(fs.ErrPermission, errA) {
+		t.Errorf("failed: naked permission is errA")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errA.MatchAlso(fs.ErrPermission, fs.ErrClosed, sliceError{"x"})
+			stderrors.Is(errors.Fast("other").With(fs.ErrClosed), errA)
+		}()
+	}
+	wg.Wait()
+
+	if !stderrors.Is(errors.Fast("other").With(fs.ErrClosed), errA) {
+		t.Errorf("failed: closed is not errA")
+	}
+}
+
+func TestFault(t *testing.T) {
+	const (
+		errA = errors.Safe1[int]("a %d")
+		errB = errors.Fast("b %s")
+	)
+
+	var fault errors.Fault
+
+	if !stderrors.As(errA.With(errors.ErrDown(err), 1), &fault) {
+		t.Fatalf("failed: not a fault")
+	}
+
+	if fault.Message() != "a 1" || len(fault.Args()) != 1 || fault.Args()[0] != 1 {
+		t.Errorf("failed: %s %v", fault.Message(), fault.Args())
+	}
+
+	if name, line := fault.Caller(); withCallers && (name != "github.com/fogfish/faults_test.TestFault" || line == 0) {
+		t.Errorf("failed: %s %d", name, line)
+	}
+
+	if !stderrors.Is(fault.Cause(), err) || fault.Class() != errors.ClassDown || fault.Code() != "" {
+		t.Errorf("failed: %v %v", fault.Cause(), fault.Class())
+	}
+
+	if !stderrors.As(errB.With(err, "b"), &fault) {
+		t.Fatalf("failed: not a fault")
+	}
+
+	if name, _ := fault.Caller(); withCallers && name != "" || fault.Message() != "b b" {
+		t.Errorf("failed: %s %s", name, fault.Message())
+	}
+}
+
+func TestMaybe(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+		errC = errors.Deep("c")
+		errD = errors.Safe1[int]("d %d")
+		errE = errors.Safe2[int, int]("e %d %d")
+	)
+
+	for _, e := range []error{
+		errA.Maybe(nil),
+		errB.Maybe(nil),
+		errC.Maybe(nil),
+		errD.Maybe(nil, 1),
+		errE.Maybe(nil, 1, 2),
+	} {
+		if e != nil {
+			t.Errorf("failed: %v is not nil", e)
+		}
+	}
+
+	for _, e := range []error{
+		errA.Maybe(err),
+		errB.Maybe(err),
+		errC.Maybe(err),
+		errD.Maybe(err, 1),
+		errE.Maybe(err, 1, 2),
+	} {
+		if !stderrors.Is(e, err) {
+			t.Errorf("failed: %v", e)
+		}
+
+		var fault errors.Fault
+		stderrors.As(e, &fault)
+		if name, _ := fault.Caller(); withCallers && name != "" && name != "github.com/fogfish/faults_test.TestMaybe" {
+			t.Errorf("failed: caller %s", name)
+		}
+	}
+
+	if errC.Maybe(err).Error() != errC.With(err).Error() {
+		t.Errorf("failed: %v", errC.Maybe(err))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+		errC = errors.Deep("c")
+		errD = errors.Safe1[int]("d %d")
+		errE = errors.Safe3[int, int, int]("e %d %d %d")
+	)
+
+	if !errA.Check(errA.With(err)) || errA.Check(errB.With(err)) {
+		t.Errorf("failed: check a")
+	}
+
+	if !errB.Check(errB.With(err)) || errB.Check(err) {
+		t.Errorf("failed: check b")
+	}
+
+	if !errC.Check(errC.With(err)) || errC.Check(errA.With(err)) {
+		t.Errorf("failed: check c")
+	}
+
+	if !errD.Check(errD.With(err, 1)) || errD.Check(errE.With(err, 1, 2, 3)) {
+		t.Errorf("failed: check d")
+	}
+
+	if !errE.Check(errE.With(err, 1, 2, 3)) || errE.Check(errD.With(err, 1)) {
+		t.Errorf("failed: check e")
+	}
+
+	if !errors.OneOf(errD.With(err, 1), errA, errB, errD) {
+		t.Errorf("failed: one of")
+	}
+
+	if errors.OneOf(errD.With(err, 1), errA, errB) || errors.OneOf(errA.With(err)) {
+		t.Errorf("failed: one of")
+	}
+}
+
+func TestValues(t *testing.T) {
+	const (
+		errA = errors.Safe1[int]("a %d")
+		errB = errors.Safe2[string, int]("b %s %d")
+		errC = errors.Safe2[string, error]("c %s %v")
+	)
+
+	e := fmt.Errorf("x: %w", errB.With(errA.With(err, 1), "b", 2))
+
+	if a, ok := errA.Values(e); !ok || a != 1 {
+		t.Errorf("failed: %v", a)
+	}
+
+	if a, b, ok := errB.Values(e); !ok || a != "b" || b != 2 {
+		t.Errorf("failed: %v %v", a, b)
+	}
+
+	if a, b, ok := errC.Values(errC.With(err, "c", nil)); !ok || a != "c" || b != nil {
+		t.Errorf("failed: %v %v", a, b)
+	}
+
+	if _, _, ok := errC.Values(e); ok {
+		t.Errorf("failed: values of c")
+	}
+}
+
+func TestWithAll(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+		errC = errors.Deep("c")
+	)
+
+	errX := stderrors.New("x")
+	errY := errors.ErrNotFound(stderrors.New("y"), "k")
+
+	for _, e := range []error{
+		errA.WithAll(errX, nil, errY),
+		errB.WithAll(errX, errY),
+		errC.WithAll(errX, errY),
+	} {
+		if !stderrors.Is(e, errX) || !errors.IsNotFound(e, "k") || !errors.OneOf(e, errA, errB, errC) {
+			t.Errorf("failed: %v", e)
+		}
+
+		if !strings.HasSuffix(e.Error(), ": [x; y]") {
+			t.Errorf("failed: %v", e)
+		}
+	}
+
+	if e := errB.WithAll(nil, errX); e.Error() != "b: x" {
+		t.Errorf("failed: %v", e)
+	}
+
+	if e := errB.WithAll(); e.Error() != "b" {
+		t.Errorf("failed: %v", e)
+	}
+
+	if name, _ := errA.WithAll(errX).(errors.Fault).Caller(); withCallers && name != "github.com/fogfish/faults_test.TestWithAll" {
+		t.Errorf("failed: caller %s", name)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Fast("b")
+		errC = errors.Deep("c")
+	)
+
+	e := errA.With(errB.WithAll(err, errC.With(nil)), 1)
+
+	if s := fmt.Sprintf("%v", e); s != e.Error() {
+		t.Errorf("failed: %s", s)
+	}
+
+	if s := fmt.Sprintf("%s", e); s != e.Error() {
+		t.Errorf("failed: %s", s)
+	}
+
+	if s := fmt.Sprintf("%q", e); s != strconv.Quote(e.Error()) {
+		t.Errorf("failed: %s", s)
+	}
+
+	lines := strings.Split(fmt.Sprintf("%+v", e), "\n")
+	if withCallers && (len(lines) < 7 ||
+		lines[0] != "a 1" ||
+		lines[1] != "\tgithub.com/fogfish/faults_test.TestFormat:"+strconv.Itoa(276) ||
+		lines[2] != "b" ||
+		lines[3] != "just error" ||
+		lines[4] != "c" ||
+		lines[5] != "\tgithub.com/fogfish/faults_test.TestFormat" ||
+		!strings.HasPrefix(lines[6], "\t\t")) {
+		t.Errorf("failed: %q", lines)
+	}
+}
+
+func TestNilSemantics(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+		errC = errors.Deep("c")
+		errD = errors.Safe1[int]("d %d")
+		errE = errors.Named("e {x}")
+	)
+
+	errF := errors.Code("F", "f")
+
+	for _, e := range []error{
+		errA.With(nil),
+		errB.With(nil),
+		errC.With(nil),
+		errD.With(nil, 1),
+		errE.With(nil, "x", 1),
+		errF.With(nil),
+	} {
+		var fault errors.Fault
+		if !stderrors.As(e, &fault) || fault.Cause() != nil {
+			t.Errorf("failed: With(nil) = %v", e)
+		}
+	}
+
+	for _, e := range []error{
+		errA.Maybe(nil),
+		errB.Maybe(nil),
+		errC.Maybe(nil),
+		errD.Maybe(nil, 1),
+		errE.Maybe(nil, "x", 1),
+		errF.Maybe(nil),
+	} {
+		if e != nil {
+			t.Errorf("failed: Maybe(nil) = %v", e)
+		}
+	}
+}
+
+const errExtend = errors.Type("extended %s")
+
+func errExtendNotFound(err error, key string) error {
+	return errors.ErrNotFound(errors.Extend(0, errExtend, err, key), key)
+}
+
+func errExtendNested(err error, key string) error {
+	return errors.Extend(1, errExtend, err, key)
+}
+
+func errExtendOuter(err error, key string) error {
+	return errExtendNested(err, key)
+}
+
+func TestExtend(t *testing.T) {
+	for _, e := range []error{
+		errExtendNotFound(err, "k"),
+		errExtendOuter(err, "k"),
+	} {
+		var fault errors.Fault
+		if !stderrors.As(e, &fault) || !errExtend.Check(e) || !stderrors.Is(e, err) {
+			t.Errorf("failed: %v", e)
+			continue
+		}
+
+		if name, _ := fault.Caller(); withCallers && name != "github.com/fogfish/faults_test.TestExtend" {
+			t.Errorf("failed: %s", name)
+		}
+
+		if fault.Message() != "extended k" {
+			t.Errorf("failed: %s", fault.Message())
+		}
+	}
+
+	if !errors.IsNotFound(errExtendNotFound(err, "k"), "k") {
+		t.Errorf("failed: not found")
+	}
+}
+
+func errNewFault(err error, key string, opts ...errors.FaultOption) error {
+	return errors.NewFault(errExtend, err, []any{key}, opts...)
+}
+
+func TestNewFault(t *testing.T) {
+	var fault errors.Fault
+
+	e := errNewFault(err, "k")
+	if !stderrors.As(e, &fault) || !errExtend.Check(e) || !stderrors.Is(e, err) || fault.Message() != "extended k" {
+		t.Errorf("failed: %v", e)
+	}
+
+	if name, _ := fault.Caller(); withCallers && name != "github.com/fogfish/faults_test.TestNewFault" {
+		t.Errorf("failed: %s", name)
+	}
+
+	if e := errNewFault(err, "k", errors.NoCaller()); e.Error() != "extended k: just error" {
+		t.Errorf("failed: %v", e)
+	}
+
+	e = errNewFault(err, "k", errors.CaptureStack())
+	if stack := errors.StackOf(e); withCallers && (len(stack) == 0 || stack[0].Function != "github.com/fogfish/faults_test.TestNewFault") {
+		t.Errorf("failed: %v", stack)
+	}
+
+	e = func() error { return errNewFault(err, "k", errors.CallerSkip(1)) }()
+	if !stderrors.As(e, &fault) {
+		t.Errorf("failed: %v", e)
+	}
+	if name, _ := fault.Caller(); withCallers && name != "github.com/fogfish/faults_test.TestNewFault" {
+		t.Errorf("failed: %s", name)
+	}
+
+	if fields := errors.Fields(errors.NewFault(errExtend, nil, []any{"k", errors.F("x", 1)})); len(fields) != 1 {
+		t.Errorf("failed: %v", fields)
+	}
+}
+
+func TestWrapOnce(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+		errC = errors.Deep("c")
+	)
+
+	e := errA.WrapOnce(errB.WrapOnce(errC.WrapOnce(err)))
+	if e.Error() != errA.WrapOnce(errB.WrapOnce(errC.WrapOnce(e))).Error() {
+		t.Errorf("failed: %v", errA.WrapOnce(errB.WrapOnce(errC.WrapOnce(e))))
+	}
+
+	inner := errA.With(errB.With(err))
+	if x := errB.WrapOnce(inner); x != inner {
+		t.Errorf("failed: %v", x)
+	}
+
+	if e := errA.WrapOnce(err); !errA.Check(e) || !stderrors.Is(e, err) {
+		t.Errorf("failed: %v", e)
+	}
+}
+
+func TestWrapOnceContexts(t *testing.T) {
+	type payload struct{ ID string }
+
+	var (
+		errA = errors.Code("E1", "a")
+		errB = errors.Named("b {key}")
+		errC = errors.Critical("c")
+		errD = errors.Of[payload]("d")
+		errE = errors.Safe1[int]("e %d")
+		errF = errors.FastSafe1[int]("f %d")
+	)
+
+	for _, wrap := range []func(error) error{
+		func(e error) error { return errA.WrapOnce(e) },
+		func(e error) error { return errB.WrapOnce(e, "key", "k") },
+		func(e error) error { return errC.WrapOnce(e) },
+		func(e error) error { return errD.WrapOnce(e, payload{"x"}) },
+		func(e error) error { return errE.WrapOnce(e, 1) },
+		func(e error) error { return errF.WrapOnce(e, 1) },
+	} {
+		e := wrap(err)
+		if e == err || !stderrors.Is(e, err) {
+			t.Errorf("failed: %v", e)
+		}
+
+		inner := errors.Type("x").With(e)
+		if x := wrap(inner); x != inner {
+			t.Errorf("failed: wrapped twice %v", x)
+		}
+	}
+}
+
+func TestRoot(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+	)
+
+	deep := fmt.Errorf("deep: %w", fs.ErrNotExist)
+
+	for _, tt := range []struct {
+		err  error
+		root error
+	}{
+		{nil, nil},
+		{err, err},
+		{errA.With(err), err},
+		{errA.With(errB.With(err)), err},
+		{errA.With(fmt.Errorf("x: %w", err)), err},
+		{errA.WithAll(err, errB.With(deep)), fs.ErrNotExist},
+		{errA.With(nil), nil},
+	} {
+		if root := errors.Root(tt.err); root != tt.root {
+			t.Errorf("failed: root of %v is %v", tt.err, root)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+	)
+
+	b := errB.With(err)
+	e := errA.WithAll(b, err, fs.ErrNotExist)
+
+	seq := errors.Flatten(e)
+	if len(seq) != 4 || seq[0] != e || seq[1] != b || seq[2] != err || seq[3] != fs.ErrNotExist {
+		t.Errorf("failed: %q", seq)
+	}
+
+	if errors.Flatten(nil) != nil {
+		t.Errorf("failed: nil")
+	}
+}
+
+// pkgCause mirrors github.com/pkg/errors.Cause
+func pkgCause(err error) error {
+	type causer interface{ Cause() error }
+
+	for err != nil {
+		cause, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return err
+}
+
+func TestPkgCause(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+	)
+
+	for _, e := range []error{
+		errA.With(err),
+		errA.With(errB.With(err)),
+		errA.With(errors.ErrTimeout(errB.With(err), time.Second)),
+		errors.ErrNotFound(errA.With(err), "k"),
+	} {
+		if cause := pkgCause(e); cause != err {
+			t.Errorf("failed: cause of %v is %v", e, cause)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build !faults_nocaller
+
+package faultstest_test
+
+// withCallers is false if the suite is built with faults_nocaller
+const withCallers = true
@@ -0,0 +1,75 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faultstest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fogfish/faults"
+)
+
+// ErrFake is the default cause of fake faults
+var ErrFake = errors.New("faultstest: fake failure")
+
+// FakeOption customizes the fault fabricated by Fake
+type FakeOption func(*fake)
+
+type fake struct {
+	cause error
+	args  []any
+}
+
+// WithCause sets the cause of the fake fault, ErrFake by default.
+func WithCause(err error) FakeOption {
+	return func(f *fake) { f.cause = err }
+}
+
+// WithArgs sets arguments of the fake fault.
+func WithArgs(args ...any) FakeOption {
+	return func(f *fake) { f.args = args }
+}
+
+// WithTimeout classifies the cause as Timeout, see faults.ErrTimeout.
+func WithTimeout(timeout time.Duration) FakeOption {
+	return func(f *fake) { f.cause = faults.ErrTimeout(f.cause, timeout) }
+}
+
+// WithStatusCode annotates the cause with the status code, see faults.ErrStatusCode.
+func WithStatusCode(code string) FakeOption {
+	return func(f *fake) { f.cause = faults.ErrStatusCode(f.cause, code) }
+}
+
+// WithNotFound classifies the cause as NotFound, see faults.ErrNotFound.
+func WithNotFound(key string) FakeOption {
+	return func(f *fake) { f.cause = faults.ErrNotFound(f.cause, key) }
+}
+
+// WithClass assigns the class to the cause, see faults.ErrClass.
+func WithClass(class faults.Class) FakeOption {
+	return func(f *fake) { f.cause = faults.ErrClass(f.cause, class) }
+}
+
+// Fake fabricates the fault of the context as if it is produced by
+// the failing dependency, so that error-handling branches of consumers are
+// tested without invoking dependencies. The caller of the fault is the caller
+// of Fake. Options are applied in the given order, behaviors wrap the cause.
+//
+//	err := faultstest.Fake(errSome,
+//		faultstest.WithArgs("key"),
+//		faultstest.WithTimeout(5*time.Second),
+//	)
+func Fake(errX error, opts ...FakeOption) error {
+	f := fake{cause: ErrFake}
+	for _, opt := range opts {
+		opt(&f)
+	}
+
+	return faults.NewFault(errX, f.cause, f.args)
+}
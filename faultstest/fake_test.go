@@ -0,0 +1,60 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faultstest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/faultstest"
+)
+
+func TestFake(t *testing.T) {
+	const errA = faults.Type("a %s")
+
+	e := faultstest.Fake(errA, faultstest.WithArgs("x"))
+
+	if !errors.Is(e, errA) || !errors.Is(e, faultstest.ErrFake) {
+		t.Errorf("failed: %v", e)
+	}
+
+	var f faults.Fault
+	if !errors.As(e, &f) {
+		t.Fatalf("failed: not a fault")
+	}
+
+	if name, _ := f.Caller(); withCallers && name != "github.com/fogfish/faults/faultstest_test.TestFake" {
+		t.Errorf("failed: caller %s", name)
+	}
+
+	if f.Message() != "a x" {
+		t.Errorf("failed: %s", f.Message())
+	}
+}
+
+func TestFakeBehaviors(t *testing.T) {
+	const errA = faults.Fast("a")
+
+	for _, tt := range []struct {
+		err error
+		is  func(error) bool
+	}{
+		{faultstest.Fake(errA, faultstest.WithTimeout(time.Second)), func(err error) bool { return faults.IsTimeout(err, time.Second) }},
+		{faultstest.Fake(errA, faultstest.WithStatusCode("503")), func(err error) bool { return faults.IsStatusCode(err, "503") }},
+		{faultstest.Fake(errA, faultstest.WithNotFound("k")), func(err error) bool { return faults.IsNotFound(err, "k") }},
+		{faultstest.Fake(errA, faultstest.WithClass(faults.ClassDown)), func(err error) bool { return faults.ClassOf(err) == faults.ClassDown }},
+		{faultstest.Fake(errA, faultstest.WithCause(err), faultstest.WithTimeout(time.Second)), func(e error) bool { return errors.Is(e, err) }},
+	} {
+		if !tt.is(tt.err) {
+			t.Errorf("failed: %v", tt.err)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// Package faultstest provides helpers to assert faults in tests. Checks
+// compare attributes of faults (class, code, arguments, cause) and report
+// precise differences instead of full-string comparisons.
+//
+//	expect := faultstest.ExpectFault(t, errSome,
+//		faultstest.Class(faults.ClassNotFound),
+//		faultstest.Arg(0, "key"),
+//	)
+//
+//	expect(doSomething())
+package faultstest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fogfish/faults"
+)
+
+// Checker asserts the error, it returns true if the error matches expectations.
+type Checker func(err error) bool
+
+// Option declares expectation about the fault, see ExpectFault.
+type Option func(*expectation)
+
+type expectation struct {
+	checks []func(t faultT, f faults.Fault)
+}
+
+// subset of testing.TB used by checkers
+type faultT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Class expects the class of the fault, see faults.ClassOf
+func Class(class faults.Class) Option {
+	return func(e *expectation) {
+		e.checks = append(e.checks, func(t faultT, f faults.Fault) {
+			t.Helper()
+			if got := f.Class(); got != class {
+				t.Errorf("faultstest: class = %q, want %q", got, class)
+			}
+		})
+	}
+}
+
+// Code expects the code of the fault, see faults.Code
+func Code(code string) Option {
+	return func(e *expectation) {
+		e.checks = append(e.checks, func(t faultT, f faults.Fault) {
+			t.Helper()
+			if got := f.Code(); got != code {
+				t.Errorf("faultstest: code = %q, want %q", got, code)
+			}
+		})
+	}
+}
+
+// Arg expects the value of positional argument of the fault
+func Arg(i int, value any) Option {
+	return func(e *expectation) {
+		e.checks = append(e.checks, func(t faultT, f faults.Fault) {
+			t.Helper()
+			args := f.Args()
+			if i < 0 || i >= len(args) {
+				t.Errorf("faultstest: arg[%d] is missing, fault has %d args", i, len(args))
+				return
+			}
+			if !reflect.DeepEqual(args[i], value) {
+				t.Errorf("faultstest: arg[%d] = %#v, want %#v", i, args[i], value)
+			}
+		})
+	}
+}
+
+// NamedArg expects the value of named argument or field of the fault,
+// see faults.Named and faults.F
+func NamedArg(key string, value any) Option {
+	return func(e *expectation) {
+		e.checks = append(e.checks, func(t faultT, f faults.Fault) {
+			t.Helper()
+			for _, field := range faults.Fields(f) {
+				if field.Key == key {
+					if !reflect.DeepEqual(field.Value, value) {
+						t.Errorf("faultstest: arg[%s] = %#v, want %#v", key, field.Value, value)
+					}
+					return
+				}
+			}
+			t.Errorf("faultstest: arg[%s] is missing", key)
+		})
+	}
+}
+
+// Cause expects the cause of the fault matches the type, using errors.As
+func Cause[T error]() Option {
+	return func(e *expectation) {
+		e.checks = append(e.checks, func(t faultT, f faults.Fault) {
+			t.Helper()
+			var target T
+			if !errors.As(f.Cause(), &target) {
+				t.Errorf("faultstest: cause %T is not %s", f.Cause(), reflect.TypeOf(&target).Elem())
+			}
+		})
+	}
+}
+
+// ExpectFault returns the checker asserting that the error carries the fault
+// produced by the context errX and that the fault matches options.
+//
+//	for _, tt := range []struct {
+//		input  string
+//		expect faultstest.Checker
+//	}{
+//		{"a", faultstest.ExpectFault(t, errSome, faultstest.Arg(0, "a"))},
+//	} {
+//		tt.expect(doSomething(tt.input))
+//	}
+func ExpectFault(t testing.TB, errX error, opts ...Option) Checker {
+	e := expectation{}
+	for _, opt := range opts {
+		opt(&e)
+	}
+
+	return func(err error) bool {
+		t.Helper()
+
+		f := faultOf(err, errX)
+		if f == nil {
+			t.Errorf("faultstest: %q is not produced by %q", err, errX)
+			return false
+		}
+
+		ok := true
+		for _, check := range e.checks {
+			c := &counter{faultT: t}
+			check(c, f)
+			ok = ok && c.n == 0
+		}
+
+		return ok
+	}
+}
+
+// counter of failed checks
+type counter struct {
+	faultT
+	n int
+}
+
+func (c *counter) Errorf(format string, args ...any) {
+	c.n++
+	c.faultT.Errorf(format, args...)
+}
+
+// faultOf returns the fault of the chain produced by the context. Faults
+// unwrap to the context followed by causes.
+func faultOf(err, errX error) faults.Fault {
+	for err != nil {
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			seq := x.Unwrap()
+			if f, ok := err.(faults.Fault); ok && len(seq) > 0 && seq[0] == errX {
+				return f
+			}
+			for _, err := range seq {
+				if f := faultOf(err, errX); f != nil {
+					return f
+				}
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faultstest_test
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/faultstest"
+)
+
+var err = fmt.Errorf("just error")
+
+// recorder of reported failures
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestExpectFault(t *testing.T) {
+	const (
+		errA = faults.Type("a %s")
+		errB = faults.Named("b {key}")
+		errD = faults.Fast("d")
+	)
+
+	errC := faults.Code("C01", "c")
+
+	for _, tt := range []struct {
+		err    error
+		expect faultstest.Checker
+	}{
+		{errA.With(err, "x"), faultstest.ExpectFault(t, errA, faultstest.Arg(0, "x"))},
+		{errD.With(errA.With(err, "x")), faultstest.ExpectFault(t, errA, faultstest.Arg(0, "x"))},
+		{errB.With(err, "key", 1), faultstest.ExpectFault(t, errB, faultstest.NamedArg("key", 1))},
+		{errA.With(err, "x", faults.F("k", "v")), faultstest.ExpectFault(t, errA, faultstest.NamedArg("k", "v"))},
+		{errC.With(err), faultstest.ExpectFault(t, errC, faultstest.Code("C01"))},
+		{errA.With(faults.ErrNotFound(err, "k"), "x"), faultstest.ExpectFault(t, errA, faultstest.Class(faults.ClassNotFound))},
+		{errD.With(&fs.PathError{Err: err}), faultstest.ExpectFault(t, errD, faultstest.Cause[*fs.PathError]())},
+	} {
+		if !tt.expect(tt.err) {
+			t.Errorf("failed: %v", tt.err)
+		}
+	}
+}
+
+func TestExpectFaultDiff(t *testing.T) {
+	const (
+		errA = faults.Type("a %s")
+		errD = faults.Fast("d")
+	)
+
+	for _, tt := range []struct {
+		err    error
+		opts   []faultstest.Option
+		expect string
+	}{
+		{errD.With(err), nil, "is not produced by"},
+		{errA.With(err, "x"), []faultstest.Option{faultstest.Arg(0, "y")}, `arg[0] = "x", want "y"`},
+		{errA.With(err, "x"), []faultstest.Option{faultstest.Arg(1, "y")}, "arg[1] is missing"},
+		{errA.With(err, "x"), []faultstest.Option{faultstest.NamedArg("k", "v")}, "arg[k] is missing"},
+		{errA.With(err, "x"), []faultstest.Option{faultstest.Class(faults.ClassConflict)}, `class = "", want "conflict"`},
+		{errA.With(err, "x"), []faultstest.Option{faultstest.Code("C01")}, `code = "", want "C01"`},
+		{errA.With(err, "x"), []faultstest.Option{faultstest.Cause[*fs.PathError]()}, "is not *fs.PathError"},
+	} {
+		r := &recorder{TB: t}
+		if faultstest.ExpectFault(r, errA, tt.opts...)(tt.err) {
+			t.Errorf("failed: %v is expected to fail", tt.err)
+		}
+		if len(r.failures) != 1 || !strings.Contains(r.failures[0], tt.expect) {
+			t.Errorf("failed: %q", r.failures)
+		}
+	}
+}
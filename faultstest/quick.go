@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faultstest
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/fogfish/faults"
+)
+
+// Chain is the arbitrary chain of faults of random depth, classes and args.
+// It implements quick.Generator for property-based tests with testing/quick.
+//
+//	prop := func(c faultstest.Chain) bool {
+//		return faults.ClassOf(errSome.With(c.Err)) == faults.ClassOf(c.Err)
+//	}
+//
+//	if err := quick.Check(prop, nil); err != nil {
+//		t.Error(err)
+//	}
+type Chain struct{ Err error }
+
+// contexts used by arbitrary chains
+const (
+	errType = faults.Type("faultstest: type %v")
+	errFast = faults.Fast("faultstest: fast %v")
+	errDeep = faults.Deep("faultstest: deep %v")
+)
+
+// Generate arbitrary chain, the size limits its depth.
+func (Chain) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Chain{Err: genChain(r, size)})
+}
+
+func genChain(r *rand.Rand, size int) error {
+	leaves := []error{ErrFake, io.EOF, fs.ErrNotExist}
+	err := leaves[r.Intn(len(leaves))]
+
+	if size <= 0 {
+		return err
+	}
+
+	for depth := r.Intn(size + 1); depth > 0; depth-- {
+		err = genWrap(r, err, size/2)
+	}
+
+	return err
+}
+
+func genWrap(r *rand.Rand, err error, size int) error {
+	switch r.Intn(14) {
+	case 0:
+		return errType.With(err, r.Int())
+	case 1:
+		return errFast.With(err, genArg(r))
+	case 2:
+		return errDeep.With(err, genArg(r))
+	case 3:
+		return errType.WithAll(err, genChain(r, size))
+	case 4:
+		return fmt.Errorf("faultstest: %w", err)
+	case 5:
+		return faults.ErrNotFound(err, fmt.Sprint(genArg(r)))
+	case 6:
+		return faults.ErrConflict(err)
+	case 7:
+		return faults.ErrPreConditionFailed(err)
+	case 8:
+		return faults.ErrGone(err)
+	case 9:
+		return faults.ErrTimeout(err, time.Duration(r.Int63n(int64(time.Minute))))
+	case 10:
+		return faults.ErrDegraded(err)
+	case 11:
+		return faults.ErrDown(err)
+	case 12:
+		return faults.ErrStatusCode(err, fmt.Sprint(400+r.Intn(200)))
+	default:
+		return faults.ErrClass(err, faults.Classes[r.Intn(len(faults.Classes))])
+	}
+}
+
+func genArg(r *rand.Rand) any {
+	switch r.Intn(3) {
+	case 0:
+		return r.Int()
+	case 1:
+		return fmt.Sprintf("arg-%x", r.Uint32())
+	default:
+		return r.Float64()
+	}
+}
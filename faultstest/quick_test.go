@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faultstest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/faultstest"
+)
+
+// wrappers that do not change behaviors of the chain
+var transparent = []func(error) error{
+	func(err error) error { return faults.Type("a").With(err) },
+	func(err error) error { return faults.Fast("b %d").With(err, 1) },
+	func(err error) error { return faults.Deep("c").With(err) },
+	func(err error) error { return faults.Named("d {k}").With(err, "k", "v") },
+	func(err error) error { return fmt.Errorf("e: %w", err) },
+}
+
+func property(t *testing.T, prop func(faultstest.Chain, func(error) error) bool) {
+	t.Helper()
+
+	for _, wrap := range transparent {
+		if err := quick.Check(func(c faultstest.Chain) bool { return prop(c, wrap) }, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestQuickClassIsStable(t *testing.T) {
+	property(t, func(c faultstest.Chain, wrap func(error) error) bool {
+		return faults.ClassOf(wrap(c.Err)) == faults.ClassOf(c.Err) &&
+			faults.Inspect(wrap(c.Err)) == faults.Inspect(c.Err)
+	})
+}
+
+func TestQuickIsPreserved(t *testing.T) {
+	property(t, func(c faultstest.Chain, wrap func(error) error) bool {
+		return errors.Is(wrap(c.Err), c.Err)
+	})
+}
+
+func TestQuickRootIsStable(t *testing.T) {
+	property(t, func(c faultstest.Chain, wrap func(error) error) bool {
+		return faults.Root(wrap(c.Err)) == faults.Root(c.Err)
+	})
+}
+
+func TestQuickFlattenExtends(t *testing.T) {
+	property(t, func(c faultstest.Chain, wrap func(error) error) bool {
+		return len(faults.Flatten(wrap(c.Err))) == len(faults.Flatten(c.Err))+1
+	})
+}
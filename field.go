@@ -0,0 +1,224 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Field is structured key-value context of the fault. Logging backends emit
+// fields as structured attributes rather than a flat string.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F creates the field, it is passed to With along with template arguments.
+// Fields are not used to render the message. The zero Field is dropped,
+// helpers return it to omit the field.
+//
+//	errSome.With(err, faults.F("user", id), faults.F("bucket", name))
+func F(key string, value any) Field { return Field{Key: key, Value: value} }
+
+// Fields returns fields of all faults in the chain, outermost first.
+// Labels are fields too, see WithLabels.
+func Fields(err error) []Field {
+	var seq []Field
+
+	walk(err, func(err error) bool {
+		switch e := err.(type) {
+		case *errType:
+			seq = append(seq, e.fields...)
+		case *labeled:
+			seq = append(seq, e.labels...)
+		}
+		return true
+	})
+
+	return seq
+}
+
+// labeled is the error annotated with labels, see WithLabels
+type labeled struct {
+	cause
+	labels []Field
+}
+
+func (e *labeled) Error() string { return e.message("labeled") }
+
+// WithLabels attaches labels (e.g. experiments or feature flags) to the error,
+// so error-rate regressions are attributed to them during rollouts. Labels
+// are surfaced by Fields and LabelsOf, the message is not changed.
+//
+//	if err := doSomething(); err != nil {
+//		return faults.WithLabels(errSome.With(err), map[string]string{"flag.new_checkout": "on"})
+//	}
+func WithLabels(err error, labels map[string]string) error {
+	if err == nil || len(labels) == 0 {
+		return err
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seq := make([]Field, len(keys))
+	for i, key := range keys {
+		seq[i] = Field{Key: key, Value: labels[key]}
+	}
+
+	return &labeled{cause: cause{err}, labels: seq}
+}
+
+// LabelsOf returns labels of the chain, the outermost label wins.
+// Use them as dimensions of error-rate metrics.
+func LabelsOf(err error) map[string]string {
+	var kv map[string]string
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(*labeled); ok {
+			if kv == nil {
+				kv = map[string]string{}
+			}
+			for _, label := range e.labels {
+				if _, has := kv[label.Key]; !has {
+					kv[label.Key] = label.Value.(string)
+				}
+			}
+		}
+		return true
+	})
+
+	return kv
+}
+
+// LogFields flattens the error into dot-notated keys matching common schemas
+// of logging pipelines (Elastic, Datadog), so dashboards facet on attributes
+// of errors:
+//
+//	fault.message          error message
+//	fault.class            class of the error, see ClassOf
+//	fault.code             code of the error, see CodeOf
+//	fault.caller           caller of the outermost fault, function:line
+//	fault.fields.<key>     fields of the chain, see Fields
+//	fault.path             services traveled by the error, see Path
+//	fault.build.version    version of the binary, see Builds
+//	fault.build.revision   revision of the binary, see Builds
+//	fault.cause.N.type     type of N-th cause in the chain
+//	fault.cause.N.message  message of N-th cause in the chain
+//	fault.cause.N.caller   caller of N-th cause if it is the fault
+//
+// Empty attributes are omitted. It returns nil if the error is nil.
+func LogFields(err error) map[string]any {
+	if err == nil {
+		return nil
+	}
+
+	kv := map[string]any{"fault.message": err.Error()}
+
+	if class := ClassOf(err); class != "" {
+		kv["fault.class"] = string(class)
+	}
+
+	if code := CodeOf(err); code != "" {
+		kv["fault.code"] = code
+	}
+
+	walk(err, func(x error) bool {
+		e, ok := x.(*errType)
+		if !ok {
+			return true
+		}
+
+		if name, line := e.Caller(); name != "" {
+			kv["fault.caller"] = name + ":" + strconv.Itoa(line)
+		}
+		return false
+	})
+
+	for _, field := range Fields(err) {
+		kv["fault.fields."+field.Key] = field.Value
+	}
+
+	if path := Path(err); len(path) > 0 {
+		kv["fault.path"] = path
+	}
+
+	if build, ok := BuildOf(err); ok {
+		if build.Version != "" {
+			kv["fault.build.version"] = build.Version
+		}
+		if build.Revision != "" {
+			kv["fault.build.revision"] = build.Revision
+		}
+	}
+
+	// the root is the fault itself, errors are not compared because
+	// they might be of non-comparable types
+	n, root := 0, true
+	walk(err, func(x error) bool {
+		if root {
+			root = false
+			return true
+		}
+
+		switch x.(type) {
+		case declaration, *errList, *labeled, *hop:
+			return true
+		}
+
+		prefix := "fault.cause." + strconv.Itoa(n)
+		kv[prefix+".type"] = fmt.Sprintf("%T", x)
+		if e, ok := x.(*errType); ok {
+			kv[prefix+".message"] = e.Message()
+			if name, line := e.location(); name != "" {
+				kv[prefix+".caller"] = name + ":" + strconv.Itoa(line)
+			}
+		} else {
+			kv[prefix+".message"] = x.Error()
+		}
+
+		n++
+		return true
+	})
+
+	return kv
+}
+
+// splitFields separates fields from arguments
+func splitFields(args []any) ([]any, []Field) {
+	n := 0
+	for _, arg := range args {
+		if _, ok := arg.(Field); ok {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return args, nil
+	}
+
+	seq := make([]any, 0, len(args)-n)
+	fields := make([]Field, 0, n)
+	for _, arg := range args {
+		if field, ok := arg.(Field); ok {
+			if field.Key != "" || field.Value != nil {
+				fields = append(fields, field)
+			}
+		} else {
+			seq = append(seq, arg)
+		}
+	}
+
+	return seq, fields
+}
@@ -0,0 +1,164 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestFields(t *testing.T) {
+	const (
+		errA = errors.Type("a %s")
+		errB = errors.Fast("b")
+	)
+
+	e := errA.With(
+		errB.With(err, errors.F("bucket", "x")),
+		errors.F("user", 1), "a", errors.F("key", "k"),
+	)
+
+	if e.Error() != at("TestFields", 27)+"a a: b: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	fields := errors.Fields(fmt.Errorf("c: %w", e))
+	expect := []errors.Field{{"user", 1}, {"key", "k"}, {"bucket", "x"}}
+	if fmt.Sprint(fields) != fmt.Sprint(expect) {
+		t.Errorf("failed: %v", fields)
+	}
+
+	if args := e.(errors.Fault).Args(); len(args) != 1 || args[0] != "a" {
+		t.Errorf("failed: %v", args)
+	}
+
+	if errors.Fields(err) != nil {
+		t.Errorf("failed: fields of foreign error")
+	}
+
+	if seq := errors.Fields(errors.Type("a %d").With(err, 1, errors.Field{})); len(seq) != 0 {
+		t.Errorf("failed: zero field %v", seq)
+	}
+}
+
+func TestWithLabels(t *testing.T) {
+	const errA = errors.Type("a")
+
+	inner := errors.WithLabels(errA.With(err), map[string]string{"flag": "off", "exp": "b"})
+	e := errors.WithLabels(errA.With(inner), map[string]string{"flag": "on"})
+
+	if !strings.HasSuffix(e.Error(), "a: just error") || !stderrors.Is(e, err) || !stderrors.Is(e, errA) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if labels := errors.LabelsOf(e); len(labels) != 2 || labels["flag"] != "on" || labels["exp"] != "b" {
+		t.Errorf("failed: %v", labels)
+	}
+
+	fields := errors.Fields(e)
+	expect := []errors.Field{{"flag", "on"}, {"exp", "b"}, {"flag", "off"}}
+	if fmt.Sprint(fields) != fmt.Sprint(expect) {
+		t.Errorf("failed: %v", fields)
+	}
+
+	if kv := errors.LogFields(e); kv["fault.fields.exp"] != "b" || kv["fault.cause.0.type"] != "*faults.errType" {
+		t.Errorf("failed: %v", kv)
+	}
+
+	if errors.WithLabels(nil, map[string]string{"flag": "on"}) != nil || errors.WithLabels(err, nil) != err {
+		t.Errorf("failed: nil")
+	}
+
+	if errors.LabelsOf(err) != nil {
+		t.Errorf("failed: labels of foreign error")
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	const (
+		errA = errors.Type("a %s")
+		errB = errors.Fast("b")
+	)
+
+	e := errA.With(errB.With(errors.ErrGone(err), errors.F("bucket", "x")), "a")
+	kv := errors.LogFields(e)
+
+	expect := map[string]any{
+		"fault.message":         e.Error(),
+		"fault.class":           "gone",
+		"fault.caller":          "github.com/fogfish/faults_test.TestLogFields:" + fmt.Sprint(94),
+		"fault.fields.bucket":   "x",
+		"fault.cause.0.type":    "*faults.errType",
+		"fault.cause.0.message": "b",
+		"fault.cause.1.type":    "faults.GoneError",
+		"fault.cause.1.message": "just error",
+		"fault.cause.2.type":    "*errors.errorString",
+		"fault.cause.2.message": "just error",
+	}
+	if !withCallers {
+		delete(expect, "fault.caller")
+	}
+
+	for key, val := range expect {
+		if kv[key] != val {
+			t.Errorf("failed: %s = %v, expected %v", key, kv[key], val)
+		}
+	}
+
+	if len(kv) != len(expect) {
+		t.Errorf("failed: %v", kv)
+	}
+
+	if kv := errors.LogFields(err); len(kv) != 1 || kv["fault.message"] != "just error" {
+		t.Errorf("failed: %v", kv)
+	}
+
+	if errors.LogFields(nil) != nil {
+		t.Errorf("failed: nil")
+	}
+}
+
+func TestLogFieldsClassified(t *testing.T) {
+	var errX = errors.Code("E1", "x %d")
+
+	e := errors.ErrNotFound(errX.With(io.EOF, 1), "k")
+	kv := errors.LogFields(e)
+
+	if kv["fault.code"] != "E1" || kv["fault.class"] != "not_found" {
+		t.Errorf("failed: %v", kv)
+	}
+
+	if withCallers && kv["fault.caller"] != "github.com/fogfish/faults_test.TestLogFieldsClassified:"+fmt.Sprint(135) {
+		t.Errorf("failed: %v", kv)
+	}
+}
+
+// sliceError is the error of non-comparable type
+type sliceError []string
+
+func (e sliceError) Error() string { return "slice" }
+
+func TestLogFieldsNonComparable(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := sliceError{"x"}
+	if kv := errors.LogFields(e); len(kv) != 1 || kv["fault.message"] != "slice" {
+		t.Errorf("failed: %v", kv)
+	}
+
+	kv := errors.LogFields(errA.With(fmt.Errorf("b: %w", e)))
+	if kv["fault.cause.1.type"] != "faults_test.sliceError" {
+		t.Errorf("failed: %v", kv)
+	}
+}
@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Dur renders duration in human friendly form (e.g. 1.2s, 350ms, 1m30s)
+// consistently across fault messages.
+//
+//	const errSlow = faults.Safe1[faults.Dur]("request is too slow (%s)")
+//	errSlow.With(err, faults.Dur(time.Since(t)))
+type Dur time.Duration
+
+func (d Dur) String() string {
+	switch v := time.Duration(d); {
+	case v < 0:
+		return "-" + Dur(-v).String()
+	case v < time.Microsecond:
+		return strconv.FormatInt(int64(v), 10) + "ns"
+	case v < time.Millisecond:
+		return decimal(float64(v)/float64(time.Microsecond)) + "µs"
+	case v < time.Second:
+		return decimal(float64(v)/float64(time.Millisecond)) + "ms"
+	case v < time.Minute:
+		return decimal(float64(v)/float64(time.Second)) + "s"
+	default:
+		return v.Round(time.Second).String()
+	}
+}
+
+// Bytes renders size in human friendly form using binary units
+// (e.g. 512B, 1.5KiB, 3.4MiB) consistently across fault messages.
+//
+//	const errLarge = faults.Safe1[faults.Bytes]("payload is too large (%s)")
+//	errLarge.With(err, faults.Bytes(len(b)))
+type Bytes int64
+
+func (n Bytes) String() string {
+	if n < 0 {
+		return "-" + (-n).String()
+	}
+
+	if n < 1024 {
+		return strconv.FormatInt(int64(n), 10) + "B"
+	}
+
+	v := float64(n)
+	for _, unit := range []string{"KiB", "MiB", "GiB", "TiB", "PiB"} {
+		v /= 1024
+		if math.Round(v*10)/10 < 1024 {
+			return decimal(v) + unit
+		}
+	}
+
+	return decimal(v/1024) + "EiB"
+}
+
+// decimal renders float with at most one decimal digit
+func decimal(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
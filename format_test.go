@@ -0,0 +1,60 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestDur(t *testing.T) {
+	for d, expect := range map[time.Duration]string{
+		0:                                     "0ns",
+		500:                                   "500ns",
+		1234 * time.Nanosecond:                "1.2µs",
+		350 * time.Millisecond:                "350ms",
+		1234 * time.Millisecond:               "1.2s",
+		-1234 * time.Millisecond:              "-1.2s",
+		90*time.Second + 400*time.Millisecond: "1m30s",
+		2 * time.Hour:                         "2h0m0s",
+	} {
+		if v := errors.Dur(d).String(); v != expect {
+			t.Errorf("failed: %s, expected %s", v, expect)
+		}
+	}
+
+	const errA = errors.Safe1[errors.Dur]("slow %s")
+	if e := errA.With(err, errors.Dur(1500*time.Millisecond)); e.(errors.Fault).Message() != "slow 1.5s" {
+		t.Errorf("failed: %s", e)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	for n, expect := range map[int64]string{
+		0:             "0B",
+		512:           "512B",
+		1024:          "1KiB",
+		1536:          "1.5KiB",
+		3565158:       "3.4MiB",
+		1024*1024 - 1: "1MiB",
+		-2048:         "-2KiB",
+		5 * (1 << 60): "5EiB",
+	} {
+		if v := errors.Bytes(n).String(); v != expect {
+			t.Errorf("failed: %s, expected %s", v, expect)
+		}
+	}
+
+	const errA = errors.Type("large %s")
+	if e := errA.With(err, errors.Bytes(2048)); e.(errors.Fault).Message() != "large 2KiB" {
+		t.Errorf("failed: %s", e)
+	}
+}
@@ -0,0 +1,99 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "sync"
+
+// Group is a collection of goroutines working on subtasks, compatible with
+// errgroup.Group. Unlike errgroup, it collects errors of all tasks and returns
+// the most informative one by priority rather than the first to fail.
+//
+//	g := faults.Group{Priority: faults.ByClass(faults.ClassDown, faults.ClassTimeout)}
+//	g.Go(func() error { ... })
+//	g.Go(func() error { ... })
+//	err := g.Wait()
+type Group struct {
+	// Priority ranks errors, Wait returns the error of the highest rank.
+	// The first to fail is returned among errors of equal rank.
+	// The first to fail is returned if priority is not defined.
+	Priority func(error) int
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// Go calls the function in a new goroutine.
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		if err := f(); err != nil {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+		}
+	}()
+}
+
+// Wait blocks until all function calls have returned, then returns
+// the error of the highest priority.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.errs) == 0 {
+		return nil
+	}
+
+	if g.Priority == nil {
+		return g.errs[0]
+	}
+
+	err, rank := g.errs[0], g.Priority(g.errs[0])
+	for _, x := range g.errs[1:] {
+		if r := g.Priority(x); r > rank {
+			err, rank = x, r
+		}
+	}
+
+	return err
+}
+
+// Errors returns all errors collected by the group in order of failure.
+func (g *Group) Errors() []error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]error(nil), g.errs...)
+}
+
+// ByClass ranks errors by class, the first class has the highest priority.
+// Unclassified errors are ranked as ClassInternal, unlisted classes have
+// the lowest priority.
+func ByClass(order ...Class) func(error) int {
+	return func(err error) int {
+		class := ClassOf(err)
+		if class == "" {
+			class = ClassInternal
+		}
+
+		for i, x := range order {
+			if x == class {
+				return len(order) - i
+			}
+		}
+
+		return 0
+	}
+}
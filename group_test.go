@@ -0,0 +1,59 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestGroup(t *testing.T) {
+	notFound := errors.ErrNotFound(err, "k")
+	down := errors.ErrDown(err)
+	timeout := errors.ErrTimeout(err, time.Second)
+
+	run := func(g *errors.Group) {
+		for i, e := range []error{notFound, nil, timeout, down} {
+			e := e
+			delay := time.Duration(i) * 10 * time.Millisecond
+			g.Go(func() error { time.Sleep(delay); return e })
+		}
+	}
+
+	g := &errors.Group{}
+	run(g)
+	if e := g.Wait(); e != notFound {
+		t.Errorf("failed: %v", e)
+	}
+
+	if len(g.Errors()) != 3 {
+		t.Errorf("failed: %v", g.Errors())
+	}
+
+	g = &errors.Group{Priority: errors.ByClass(errors.ClassInternal, errors.ClassDown, errors.ClassTimeout)}
+	run(g)
+	if e := g.Wait(); e != down {
+		t.Errorf("failed: %v", e)
+	}
+
+	g = &errors.Group{Priority: errors.ByClass(errors.ClassInternal, errors.ClassDown)}
+	g.Go(func() error { return down })
+	g.Go(func() error { time.Sleep(10 * time.Millisecond); return err })
+	if e := g.Wait(); e != err {
+		t.Errorf("failed: %v", e)
+	}
+
+	g = &errors.Group{}
+	g.Go(func() error { return nil })
+	if e := g.Wait(); e != nil {
+		t.Errorf("failed: %v", e)
+	}
+}
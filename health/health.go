@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// Package health evaluates readiness of the service from faults of its
+// dependencies. Faults are attributed to dependencies by the label given
+// at wrap time, the dependency is unhealthy if it produces too many faults
+// of the class within the time window.
+//
+//	reporter := health.New(health.Rule{Class: faults.ClassDown, Count: 5, Window: 30 * time.Second})
+//
+//	if err := db.Get(ctx, key); err != nil {
+//		err = health.Label(errSome.With(err), "db")
+//		reporter.Add(err)
+//	}
+//
+//	http.Handle("/ready", reporter)
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fogfish/faults"
+)
+
+// Dependency is the label of faults identifying the dependency
+const Dependency = "dependency"
+
+// Label attributes the error to the dependency, see faults.WithLabels.
+func Label(err error, dependency string) error {
+	return faults.WithLabels(err, map[string]string{Dependency: dependency})
+}
+
+// Rule marks the dependency unhealthy after Count faults of the Class
+// within the Window.
+type Rule struct {
+	Class  faults.Class
+	Count  int
+	Window time.Duration
+}
+
+// DefaultRule marks the dependency unhealthy after 5 Down faults in 30 seconds
+var DefaultRule = Rule{Class: faults.ClassDown, Count: 5, Window: 30 * time.Second}
+
+type event struct {
+	class faults.Class
+	at    time.Time
+}
+
+// Reporter aggregates recent fault classes per dependency.
+type Reporter struct {
+	rules  []Rule
+	window time.Duration
+
+	// Clock of the reporter, time.Now by default
+	Clock func() time.Time
+
+	mu     sync.Mutex
+	events map[string][]event
+}
+
+// New creates the reporter, DefaultRule is used if rules are not given.
+func New(rules ...Rule) *Reporter {
+	if len(rules) == 0 {
+		rules = []Rule{DefaultRule}
+	}
+
+	r := &Reporter{
+		rules:  rules,
+		Clock:  time.Now,
+		events: map[string][]event{},
+	}
+
+	for _, rule := range rules {
+		if rule.Window > r.window {
+			r.window = rule.Window
+		}
+	}
+
+	return r
+}
+
+// Add the fault of the dependency, errors without the dependency label or
+// class are ignored.
+func (r *Reporter) Add(err error) {
+	dependency := faults.LabelsOf(err)[Dependency]
+	if dependency == "" {
+		return
+	}
+
+	class := faults.ClassOf(err)
+	if class == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.Clock()
+	r.events[dependency] = append(r.prune(dependency, now), event{class: class, at: now})
+}
+
+// prune events beyond the widest window
+func (r *Reporter) prune(dependency string, now time.Time) []event {
+	seq := r.events[dependency]
+
+	i := 0
+	for i < len(seq) && now.Sub(seq[i].at) >= r.window {
+		i++
+	}
+
+	return seq[i:]
+}
+
+// Status returns health of known dependencies, the value is the violated
+// rule or empty string if the dependency is healthy.
+func (r *Reporter) Status() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.Clock()
+	status := make(map[string]string, len(r.events))
+
+	for dependency := range r.events {
+		seq := r.prune(dependency, now)
+		r.events[dependency] = seq
+		status[dependency] = ""
+
+		for _, rule := range r.rules {
+			n := 0
+			for _, e := range seq {
+				if e.class == rule.Class && now.Sub(e.at) < rule.Window {
+					n++
+				}
+			}
+
+			if n >= rule.Count {
+				status[dependency] = fmt.Sprintf("%d %s faults in %s", n, rule.Class, rule.Window)
+				break
+			}
+		}
+	}
+
+	return status
+}
+
+// Ready returns the error listing unhealthy dependencies, nil if all of them
+// are healthy. Use it as the readiness check.
+func (r *Reporter) Ready() error {
+	var seq []string
+	for dependency, violation := range r.Status() {
+		if violation != "" {
+			seq = append(seq, dependency+": "+violation)
+		}
+	}
+
+	if len(seq) == 0 {
+		return nil
+	}
+
+	sort.Strings(seq)
+	return fmt.Errorf("unhealthy dependencies: %s", strings.Join(seq, "; "))
+}
+
+// ServeHTTP is the readiness endpoint, it responds 200 OK if all dependencies
+// are healthy, 503 Service Unavailable otherwise. The body is the JSON status.
+func (r *Reporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	status := r.Status()
+
+	code := http.StatusOK
+	for _, violation := range status {
+		if violation != "" {
+			code = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
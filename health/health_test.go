@@ -0,0 +1,64 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package health_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/health"
+)
+
+var err = fmt.Errorf("just error")
+
+func TestReporter(t *testing.T) {
+	const errA = faults.Type("a")
+
+	now := time.Now()
+	reporter := health.New(health.Rule{Class: faults.ClassDown, Count: 2, Window: time.Minute})
+	reporter.Clock = func() time.Time { return now }
+
+	down := health.Label(errA.With(faults.ErrDown(err)), "db")
+
+	reporter.Add(down)
+	reporter.Add(health.Label(errA.With(faults.ErrConflict(err)), "db"))
+	reporter.Add(health.Label(errA.With(faults.ErrDown(err)), "cache"))
+	reporter.Add(errA.With(faults.ErrDown(err)))
+
+	if status := reporter.Status(); len(status) != 2 || status["db"] != "" || status["cache"] != "" || reporter.Ready() != nil {
+		t.Errorf("failed: %v", status)
+	}
+
+	now = now.Add(time.Second)
+	reporter.Add(down)
+
+	if err := reporter.Ready(); err == nil || err.Error() != "unhealthy dependencies: db: 2 down faults in 1m0s" {
+		t.Errorf("failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	reporter.ServeHTTP(w, httptest.NewRequest("GET", "/ready", nil))
+	if w.Code != 503 {
+		t.Errorf("failed: %d %s", w.Code, w.Body.String())
+	}
+
+	now = now.Add(time.Minute)
+	if err := reporter.Ready(); err != nil {
+		t.Errorf("failed: faults are not expired %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	reporter.ServeHTTP(w, httptest.NewRequest("GET", "/ready", nil))
+	if w.Code != 200 {
+		t.Errorf("failed: %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,109 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"cmp"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HeatmapEntry is the number of faults produced by the call site
+type HeatmapEntry struct {
+	Caller string `json:"caller"`
+	Count  int    `json:"count"`
+}
+
+// HeatmapReport is faults per call site, the noisiest first
+type HeatmapReport struct {
+	Since   time.Time      `json:"since"`
+	Callers []HeatmapEntry `json:"callers"`
+}
+
+// Heatmap counts faults per call site over the time window, so developers
+// find the noisiest error-producing lines in the running service. Faults
+// without callers (e.g. Fast) are not counted. It is the http.Handler of
+// the debug page rendering the report as JSON.
+//
+//	heatmap := &faults.Heatmap{Window: time.Hour}
+//	faults.Configure(faults.Observe(heatmap.Observe))
+//
+//	http.Handle("/debug/faults", heatmap)
+type Heatmap struct {
+	// Window of counting, counts are reset once it elapses. Zero never resets.
+	Window time.Duration
+
+	mu     sync.Mutex
+	since  time.Time
+	counts map[uintptr]int
+}
+
+// Observe is the observer of faults, see faults.Observe
+func (h *Heatmap) Observe(f Fault) {
+	e, ok := f.(*errType)
+	if !ok || e.pc == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.rotate()
+	h.counts[e.pc]++
+}
+
+// rotate starts the new window once the current one elapses
+func (h *Heatmap) rotate() {
+	now := cfg.Load().clock()
+	if h.counts == nil || (h.Window > 0 && now.Sub(h.since) >= h.Window) {
+		h.since = now
+		h.counts = map[uintptr]int{}
+	}
+}
+
+// Report returns faults per call site of the current window. Call sites are
+// resolved while reporting, counting is cheap.
+func (h *Heatmap) Report() HeatmapReport {
+	h.mu.Lock()
+	h.rotate()
+	since := h.since
+	pcs := maps.Clone(h.counts)
+	h.mu.Unlock()
+
+	counts := make(map[string]int, len(pcs))
+	for pc, n := range pcs {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		counts[frame.Function+":"+strconv.Itoa(frame.Line)] += n
+	}
+
+	seq := make([]HeatmapEntry, 0, len(counts))
+	for caller, n := range counts {
+		seq = append(seq, HeatmapEntry{Caller: caller, Count: n})
+	}
+
+	slices.SortFunc(seq, func(a, b HeatmapEntry) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Caller, b.Caller)
+	})
+
+	return HeatmapReport{Since: since, Callers: seq}
+}
+
+func (h *Heatmap) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Report())
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestHeatmap(t *testing.T) {
+	requireCallers(t)
+
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+	)
+
+	now := time.Now()
+	heatmap := &errors.Heatmap{Window: time.Minute}
+
+	errors.Configure(
+		errors.Observe(heatmap.Observe),
+		errors.Clock(func() time.Time { return now }),
+	)
+	defer errors.Configure(errors.Observe(), errors.Clock(nil))
+
+	for i := 0; i < 3; i++ {
+		errA.With(err)
+	}
+	errA.With(err)
+	errB.With(err)
+
+	report := heatmap.Report()
+	if !report.Since.Equal(now) || len(report.Callers) != 2 ||
+		report.Callers[0] != (errors.HeatmapEntry{Caller: fmt.Sprintf("github.com/fogfish/faults_test.TestHeatmap:%d", 39), Count: 3}) ||
+		report.Callers[1].Count != 1 {
+		t.Errorf("failed: %+v", report)
+	}
+
+	w := httptest.NewRecorder()
+	heatmap.ServeHTTP(w, httptest.NewRequest("GET", "/debug/faults", nil))
+
+	var served errors.HeatmapReport
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil || len(served.Callers) != 2 {
+		t.Errorf("failed: %s", w.Body.String())
+	}
+
+	now = now.Add(time.Minute)
+	if report := heatmap.Report(); len(report.Callers) != 0 || !report.Since.Equal(now) {
+		t.Errorf("failed: window is not reset %+v", report)
+	}
+}
@@ -0,0 +1,51 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "regexp"
+
+// Heuristic guesses the class of the error from its message.
+type Heuristic struct {
+	Pattern *regexp.Regexp
+	Class   Class
+}
+
+// Heuristics is an opt-in, table-driven classifier of foreign errors. It is
+// useful at system edges where upstream libraries give only strings.
+// The first matching heuristic wins.
+type Heuristics []Heuristic
+
+// DefaultHeuristics covers common messages of network and storage errors.
+var DefaultHeuristics = Heuristics{
+	{regexp.MustCompile(`(?i)connection refused|no such host|network is unreachable|broken pipe`), ClassDown},
+	{regexp.MustCompile(`(?i)timeout|timed out|deadline exceeded`), ClassTimeout},
+	{regexp.MustCompile(`(?i)service unavailable|too many requests|throttl|overload`), ClassDegraded},
+	{regexp.MustCompile(`(?i)not found|no such file|does not exist`), ClassNotFound},
+	{regexp.MustCompile(`(?i)conflict|already exists`), ClassConflict},
+	{regexp.MustCompile(`(?i)precondition`), ClassPreConditionFailed},
+}
+
+// Classify assigns the class guessed from the message to the unclassified
+// error. Classified errors are returned unchanged.
+//
+//	err = faults.DefaultHeuristics.Classify(err)
+func (h Heuristics) Classify(err error) error {
+	if err == nil || ClassOf(err) != "" {
+		return err
+	}
+
+	msg := err.Error()
+	for _, x := range h {
+		if x.Pattern.MatchString(msg) {
+			return ErrClass(err, x.Class)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestHeuristics(t *testing.T) {
+	for msg, class := range map[string]errors.Class{
+		"dial tcp 127.0.0.1:80: connect: connection refused": errors.ClassDown,
+		"i/o timeout":                        errors.ClassTimeout,
+		"503 Service Unavailable":            errors.ClassDegraded,
+		"open /x: no such file or directory": errors.ClassNotFound,
+		"key already exists":                 errors.ClassConflict,
+		"something else":                     "",
+	} {
+		cause := stderrors.New(msg)
+		e := errors.DefaultHeuristics.Classify(cause)
+		if errors.ClassOf(e) != class || !stderrors.Is(e, cause) || e.Error() != msg {
+			t.Errorf("failed: %s is %s", msg, errors.ClassOf(e))
+		}
+	}
+
+	// classified errors are not changed
+	e := errors.ErrConflict(stderrors.New("timeout"))
+	if x := errors.DefaultHeuristics.Classify(e); x != e {
+		t.Errorf("failed: %v", x)
+	}
+
+	if errors.DefaultHeuristics.Classify(nil) != nil {
+		t.Errorf("failed: nil")
+	}
+}
+
+func TestErrClass(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrClass(err, errors.ClassDown))
+	if errors.ClassOf(e) != errors.ClassDown || errors.IsDown(e) {
+		t.Errorf("failed: %v", errors.ClassOf(e))
+	}
+
+	e = errors.ErrClass(errors.ErrConflict(err), errors.ClassInternal)
+	if errors.ClassOf(e) != errors.ClassInternal || !errors.IsConflict(e) {
+		t.Errorf("failed: %v", errors.ClassOf(e))
+	}
+
+	if errors.ErrClass(nil, errors.ClassDown) != nil {
+		t.Errorf("failed: nil")
+	}
+}
@@ -0,0 +1,215 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Class is a coarse-grained category of the error derived from its behaviors.
+// The zero value stands for unclassified error.
+type Class string
+
+const (
+	ClassNotFound           = Class("not_found")
+	ClassConflict           = Class("conflict")
+	ClassPreConditionFailed = Class("precondition_failed")
+	ClassGone               = Class("gone")
+	ClassTimeout            = Class("timeout")
+	ClassNearTimeout        = Class("near_timeout")
+	ClassDegraded           = Class("degraded")
+	ClassDown               = Class("down")
+	ClassInternal           = Class("internal")
+)
+
+// Classes is the list of all known classes
+var Classes = []Class{
+	ClassNotFound,
+	ClassConflict,
+	ClassPreConditionFailed,
+	ClassGone,
+	ClassTimeout,
+	ClassNearTimeout,
+	ClassDegraded,
+	ClassDown,
+	ClassInternal,
+}
+
+// Inspection is behaviors of the error resolved by Inspect. Each field
+// is defined by the first error in the chain implementing the behavior,
+// exactly as corresponding IsXxx predicate does.
+type Inspection struct {
+	NotFound           string
+	Conflict           bool
+	PreConditionFailed bool
+	Gone               bool
+	Timeout            time.Duration
+	NearTimeout        time.Duration
+	Degraded           bool
+	Down               bool
+	Retryable          bool
+	Temporary          bool
+	StatusCode         string
+	RetryAfter         time.Duration
+
+	// Class of the first error in the chain with any of behaviors
+	Class Class
+}
+
+// Inspect resolves all behaviors of the error in a single walk through
+// the chain. Use it instead of series of IsXxx predicates on deep chains.
+//
+//	switch x := faults.Inspect(err); {
+//	case x.NotFound != "":
+//	case x.Conflict:
+//	}
+//
+// The result is cached on faults produced by this library, repeated calls
+// by different layers do not walk the chain again. The cache is invalidated
+// once sentinels (see RegisterSentinel) or mappings (see LoadMappings) change.
+func Inspect(err error) Inspection {
+	if e, ok := err.(*errType); ok {
+		gen := generation.Load()
+		if x := e.inspection.Load(); x != nil && x.gen == gen {
+			return x.Inspection
+		}
+
+		x := inspect(err)
+		e.inspection.Store(&inspected{gen: gen, Inspection: x})
+		return x
+	}
+
+	return inspect(err)
+}
+
+// generation of registries the inspection depends on (sentinels, mappings),
+// cached inspections of older generations are stale.
+var generation atomic.Uint64
+
+// inspected is the inspection cached on the fault
+type inspected struct {
+	gen uint64
+	Inspection
+}
+
+func inspect(err error) Inspection {
+	var (
+		x    Inspection
+		seen uint16
+	)
+
+	const (
+		hasNotFound = 1 << iota
+		hasConflict
+		hasPreConditionFailed
+		hasGone
+		hasTimeout
+		hasNearTimeout
+		hasDegraded
+		hasDown
+		hasRetryable
+		hasTemporary
+		hasStatusCode
+	)
+
+	classify := func(ok bool, c Class) {
+		if ok && x.Class == "" {
+			x.Class = c
+		}
+	}
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ FaultClass() Class }); ok {
+			classify(e.FaultClass() != "", e.FaultClass())
+		}
+
+		if c := sentinelClass(err); c != "" {
+			classify(true, c)
+		}
+
+		if e, ok := err.(interface{ NotFound() string }); ok && seen&hasNotFound == 0 {
+			seen |= hasNotFound
+			x.NotFound = e.NotFound()
+			classify(x.NotFound != "", ClassNotFound)
+		}
+
+		if e, ok := err.(interface{ Conflict() bool }); ok && seen&hasConflict == 0 {
+			seen |= hasConflict
+			x.Conflict = e.Conflict()
+			classify(x.Conflict, ClassConflict)
+		}
+
+		if e, ok := err.(interface{ PreConditionFailed() bool }); ok && seen&hasPreConditionFailed == 0 {
+			seen |= hasPreConditionFailed
+			x.PreConditionFailed = e.PreConditionFailed()
+			classify(x.PreConditionFailed, ClassPreConditionFailed)
+		}
+
+		if e, ok := err.(interface{ Gone() bool }); ok && seen&hasGone == 0 {
+			seen |= hasGone
+			x.Gone = e.Gone()
+			classify(x.Gone, ClassGone)
+		}
+
+		if e, ok := err.(interface{ Timeout() time.Duration }); ok && seen&hasTimeout == 0 {
+			seen |= hasTimeout
+			x.Timeout = e.Timeout()
+			classify(x.Timeout > 0, ClassTimeout)
+		}
+
+		if e, ok := err.(interface{ NearTimeout() time.Duration }); ok && seen&hasNearTimeout == 0 {
+			seen |= hasNearTimeout
+			x.NearTimeout = e.NearTimeout()
+			classify(x.NearTimeout > 0, ClassNearTimeout)
+		}
+
+		if e, ok := err.(interface{ Degraded() bool }); ok && seen&hasDegraded == 0 {
+			seen |= hasDegraded
+			x.Degraded = e.Degraded()
+			classify(x.Degraded, ClassDegraded)
+		}
+
+		if e, ok := err.(interface{ Down() bool }); ok && seen&hasDown == 0 {
+			seen |= hasDown
+			x.Down = e.Down()
+			classify(x.Down, ClassDown)
+		}
+
+		if e, ok := err.(interface{ Retryable() bool }); ok && seen&hasRetryable == 0 {
+			seen |= hasRetryable
+			x.Retryable = e.Retryable()
+		}
+
+		if e, ok := err.(interface{ Temporary() bool }); ok && seen&hasTemporary == 0 {
+			seen |= hasTemporary
+			x.Temporary = e.Temporary()
+		}
+
+		if e, ok := err.(interface{ StatusCode() string }); ok && seen&hasStatusCode == 0 {
+			seen |= hasStatusCode
+			x.StatusCode = e.StatusCode()
+		}
+
+		if e, ok := err.(interface{ RetryAfter() time.Duration }); ok && x.RetryAfter == 0 {
+			x.RetryAfter = e.RetryAfter()
+		}
+
+		return true
+	})
+
+	if seen&hasRetryable == 0 && x.Class != "" {
+		x.Retryable = mappingOf(x.Class).Retryable
+	}
+
+	return x
+}
+
+// ClassOf returns class of the error, see Inspect.
+func ClassOf(err error) Class { return Inspect(err).Class }
@@ -0,0 +1,100 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+type notFound string
+
+func (e notFound) Error() string    { return "not found" }
+func (e notFound) NotFound() string { return string(e) }
+
+type conflict bool
+
+func (e conflict) Error() string  { return "conflict" }
+func (e conflict) Conflict() bool { return bool(e) }
+
+type timeout time.Duration
+
+func (e timeout) Error() string          { return "timeout" }
+func (e timeout) Timeout() time.Duration { return time.Duration(e) }
+
+func TestInspect(t *testing.T) {
+	const errA = errors.Type("a")
+
+	x := errors.Inspect(errA.With(notFound("k")))
+	if x.NotFound != "k" || x.Conflict || x.Class != errors.ClassNotFound {
+		t.Errorf("failed: %+v", x)
+	}
+
+	x = errors.Inspect(errA.With(errors.ErrDown(timeout(time.Second))))
+	if x.Timeout != time.Second || !x.Down || x.Class != errors.ClassDown {
+		t.Errorf("failed: %+v", x)
+	}
+
+	// first implementer defines the behavior as errors.As does
+	x = errors.Inspect(errA.With(fmt.Errorf("%w: %w", conflict(false), conflict(true))))
+	if x.Conflict || x.Class != "" {
+		t.Errorf("failed: %+v", x)
+	}
+
+	x = errors.Inspect(errA.With(err))
+	if x != (errors.Inspection{}) {
+		t.Errorf("failed: %+v", x)
+	}
+
+	if errors.ClassOf(errA.With(errors.ErrDegraded(err))) != errors.ClassDegraded {
+		t.Errorf("failed: class of degraded")
+	}
+
+	e := errA.With(notFound("k"))
+	if errors.Inspect(e) != errors.Inspect(e) {
+		t.Errorf("failed: cached inspection")
+	}
+}
+
+func failChain() error {
+	e := errors.ErrDown(timeout(time.Second))
+	for i := 0; i < 10; i++ {
+		e = errFast.With(e)
+	}
+	return e
+}
+
+func BenchmarkIsXxx(b *testing.B) {
+	e := failChain()
+
+	for n := 0; n < b.N; n++ {
+		_ = errors.IsNotFound(e) || errors.IsConflict(e) || errors.IsGone(e) ||
+			errors.IsPreConditionFailed(e) || errors.IsTimeout(e, 0) || errors.IsDown(e)
+	}
+}
+
+func BenchmarkInspect(b *testing.B) {
+	// foreign root of the chain disables caching
+	e := fmt.Errorf("deep: %w", failChain())
+
+	for n := 0; n < b.N; n++ {
+		_ = errors.Inspect(e)
+	}
+}
+
+func BenchmarkInspectCached(b *testing.B) {
+	e := failChain()
+
+	for n := 0; n < b.N; n++ {
+		_ = errors.Inspect(e)
+	}
+}
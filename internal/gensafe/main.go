@@ -0,0 +1,213 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// The command generates type safe error contexts SafeN or FastSafeN.
+//
+//	go run ./internal/gensafe -n 10 -o safe.go
+//	go run ./internal/gensafe -n 10 -fast -o fastsafe.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Arity of contexts that supports deprecated New
+const deprecated = 5
+
+type Safe struct {
+	N    int
+	Fast bool
+	Args []Arg
+}
+
+// Name of the context, e.g. Safe2 or FastSafe2
+func (s Safe) Name() string {
+	if s.Fast {
+		return "FastSafe" + strconv.Itoa(s.N)
+	}
+	return "Safe" + strconv.Itoa(s.N)
+}
+
+type Arg struct{ Var, Type string }
+
+// Type is type declaration of the context, e.g. Safe2[A, B]
+func (s Safe) Type() string {
+	seq := make([]string, len(s.Args))
+	for i, arg := range s.Args {
+		seq[i] = arg.Type
+	}
+	return strings.Join(seq, ", ")
+}
+
+// Vars is list of arguments, e.g. a, b
+func (s Safe) Vars() string {
+	seq := make([]string, len(s.Args))
+	for i, arg := range s.Args {
+		seq[i] = arg.Var
+	}
+	return strings.Join(seq, ", ")
+}
+
+// Params is list of typed arguments, e.g. a A, b B
+func (s Safe) Params() string {
+	seq := make([]string, len(s.Args))
+	for i, arg := range s.Args {
+		seq[i] = arg.Var + " " + arg.Type
+	}
+	return strings.Join(seq, ", ")
+}
+
+func (s Safe) Deprecated() bool { return !s.Fast && s.N <= deprecated }
+
+func main() {
+	n := flag.Int("n", 10, "max arity of contexts")
+	o := flag.String("o", "safe.go", "output file")
+	fast := flag.Bool("fast", false, "generate contexts without caller capture")
+	flag.Parse()
+
+	seq := make([]Safe, *n)
+	for i := range seq {
+		seq[i].N = i + 1
+		seq[i].Fast = *fast
+		for k := 0; k <= i; k++ {
+			seq[i].Args = append(seq[i].Args, Arg{
+				Var:  string(rune('a' + k)),
+				Type: string(rune('A' + k)),
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, seq); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*o, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var tmpl = template.Must(template.New("safe").Parse(`//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// Code generated by internal/gensafe. DO NOT EDIT.
+
+package faults
+
+import (
+	"errors"
+	"fmt"
+)
+{{range .}}
+// {{.Name}} creates an error context with {{.N}} argument
+{{- if .Fast}}, it skips caller
+// capture on hot paths keeping type checking of arguments, see Fast
+{{- end}}
+{{- if eq .N 1}}
+//
+//	const errSome = errors.{{.Name}}[string]("something is failed %s")
+{{- end}}
+type {{.Name}}[{{.Type}} any] string
+
+// With wraps error into the context.
+{{- if eq .N 1}}
+// The function expands the context with arguments.
+//
+//	if err := doSomething(); err != nil {
+//		return nil, errSome.With(err, "foo")
+//	}
+{{- end}}
+func (safe {{.Name}}[{{.Type}}]) With(err error, {{.Params}}) error {
+	return {{if .Fast}}seal({{else}}withCaller(0, {{end}}newFault(safe, err, []any{ {{- .Vars -}} }))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (safe {{.Name}}[{{.Type}}]) Maybe(err error, {{.Params}}) error {
+	if err == nil {
+		return nil
+	}
+
+	return {{if .Fast}}seal({{else}}withCaller(0, {{end}}newFault(safe, err, []any{ {{- .Vars -}} }))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (safe {{.Name}}[{{.Type}}]) WrapOnce(err error, {{.Params}}) error {
+	if carries(err, safe) {
+		return err
+	}
+
+	return {{if .Fast}}seal({{else}}withCaller(0, {{end}}newFault(safe, err, []any{ {{- .Vars -}} }))
+}
+{{if .Deprecated}}
+// Deprecated: Use With
+func (safe {{.Name}}[{{.Type}}]) New(err error, {{.Params}}) error {
+	return safe.With(err, {{.Vars}})
+}
+{{end}}
+// Values returns typed arguments of the first error in the chain that is
+// wrapped with the context.
+{{- if eq .N 1}}
+//
+//	if a, ok := errSome.Values(err); ok {
+//		...
+//	}
+{{- end}}
+func (safe {{.Name}}[{{.Type}}]) Values(err error) ({{.Params}}, ok bool) {
+	walk(err, func(err error) bool {
+		x, is := err.(*errType)
+		if !is || x.head != safe || len(x.args) != {{.N}} {
+			return true
+		}
+	{{range $i, $a := .Args}}
+		{{$a.Var}}, _ = x.args[{{$i}}].({{$a.Type}})
+	{{- end}}
+		ok = true
+		return false
+	})
+
+	return
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (safe {{.Name}}[{{.Type}}]) Check(err error) bool { return errors.Is(err, safe) }
+
+func (safe {{.Name}}[{{.Type}}]) Error() string { return string(safe) }
+func (safe {{.Name}}[{{.Type}}]) arity() int { return {{.N}} }
+
+func (safe {{.Name}}[{{.Type}}]) zero() string {
+	var (
+	{{- range .Args}}
+		{{.Var}} {{.Type}}
+	{{- end}}
+	)
+	return fmt.Sprintf(string(safe), {{.Vars}})
+}
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (safe {{.Name}}[{{.Type}}]) MatchAlso(errs ...error) {{.Name}}[{{.Type}}] {
+	matchAlso(safe, errs)
+	return safe
+}
+{{end}}`))
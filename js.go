@@ -0,0 +1,102 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build js && wasm
+
+package faults
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// ToJS converts the error into JavaScript Error, so that Go code compiled
+// to the browser surfaces structured faults to JavaScript callers. The error
+// has the property detail `{code, class, message, fields}`. It returns
+// js.Null() if the error is nil.
+//
+//	return faults.ToJS(err)
+func ToJS(err error) js.Value {
+	if err == nil {
+		return js.Null()
+	}
+
+	detail := map[string]any{
+		"code":    CodeOf(err),
+		"class":   string(ClassOf(err)),
+		"message": PublicMessage(err),
+	}
+
+	if fields := Fields(err); len(fields) > 0 {
+		kv := make(map[string]any, len(fields))
+		for _, field := range fields {
+			kv[field.Key] = jsValueOf(field.Value)
+		}
+		detail["fields"] = kv
+	}
+
+	e := js.Global().Get("Error").New(err.Error())
+	e.Set("detail", js.ValueOf(detail))
+
+	return e
+}
+
+// jsValueOf converts values unsupported by js.ValueOf into strings
+func jsValueOf(v any) any {
+	switch v.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64, string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FromJS converts JavaScript Error into the fault. The code and the class
+// of the property detail are preserved, see ToJS. It returns nil if the value
+// is null or undefined.
+//
+//	if err := faults.FromJS(promise.Await()); err != nil {
+//		...
+//	}
+func FromJS(v js.Value) error {
+	if v.IsNull() || v.IsUndefined() {
+		return nil
+	}
+
+	msg := v.String()
+	if m := v.Get("message"); m.Type() == js.TypeString {
+		msg = m.String()
+	}
+
+	var (
+		code  string
+		class Class
+	)
+
+	if detail := v.Get("detail"); detail.Type() == js.TypeObject {
+		if c := detail.Get("code"); c.Type() == js.TypeString {
+			code = c.String()
+		}
+		if c := detail.Get("class"); c.Type() == js.TypeString {
+			class = Class(c.String())
+		}
+	}
+
+	var decl error = Fast(msg)
+	if code != "" {
+		decl = Code(code, msg)
+	}
+
+	var err error = seal(newFault(decl, nil, nil))
+
+	if class != "" {
+		err = ErrClass(err, class)
+	}
+
+	return err
+}
@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build js && wasm
+
+package faults_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestJS(t *testing.T) {
+	errA := errors.Code("E1", "a %s")
+
+	v := errors.ToJS(errors.ErrGone(errA.With(err, "x", errors.F("user", "u"))))
+
+	if v.Get("detail").Get("code").String() != "E1" ||
+		v.Get("detail").Get("class").String() != "gone" ||
+		v.Get("detail").Get("fields").Get("user").String() != "u" {
+		t.Errorf("failed: %v", v)
+	}
+
+	var seq []errors.Fault
+	errors.Configure(errors.Observe(func(f errors.Fault) { seq = append(seq, f) }))
+	defer errors.Configure(errors.Observe())
+
+	e := errors.FromJS(v)
+	if errors.CodeOf(e) != "E1" || errors.ClassOf(e) != errors.ClassGone {
+		t.Errorf("failed: %v", e)
+	}
+
+	if len(seq) != 1 || errors.CodeOf(seq[0]) != "E1" {
+		t.Errorf("failed: observed %v", seq)
+	}
+
+	if errors.FromJS(js.Null()) != nil || !errors.ToJS(nil).IsNull() {
+		t.Errorf("failed: nil")
+	}
+}
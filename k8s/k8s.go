@@ -0,0 +1,138 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// Package k8s maps Kubernetes api errors (k8s.io/apimachinery) into faults
+// behaviors and vice versa, so that operators and controllers deal with
+// single error taxonomy.
+package k8s
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fogfish/faults"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Classify annotates Kubernetes api error with faults behaviors
+// (NotFound, Conflict, Gone, Timeout, RateLimited, StatusCode) and retry-after
+// hints. Other errors are returned unchanged.
+//
+//	if err := client.Get(ctx, key, obj); err != nil {
+//		return k8s.Classify(err)
+//	}
+func Classify(err error) error {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return err
+	}
+
+	e := &apiError{error: err, status: status.Status()}
+
+	switch e.status.Reason {
+	case metav1.StatusReasonTimeout, metav1.StatusReasonServerTimeout:
+		return &apiTimeout{e}
+	default:
+		return e
+	}
+}
+
+type apiError struct {
+	error
+	status metav1.Status
+}
+
+func (e *apiError) Unwrap() error { return e.error }
+
+func (e *apiError) NotFound() string {
+	if e.status.Reason != metav1.StatusReasonNotFound {
+		return ""
+	}
+
+	if e.status.Details != nil && e.status.Details.Name != "" {
+		return e.status.Details.Name
+	}
+
+	return e.status.Message
+}
+
+func (e *apiError) Conflict() bool {
+	return e.status.Reason == metav1.StatusReasonConflict
+}
+
+func (e *apiError) Gone() bool {
+	return e.status.Reason == metav1.StatusReasonGone ||
+		e.status.Reason == metav1.StatusReasonExpired
+}
+
+func (e *apiError) RateLimited() bool {
+	return e.status.Reason == metav1.StatusReasonTooManyRequests
+}
+
+func (e *apiError) RetryAfter() time.Duration {
+	if e.status.Details == nil {
+		return 0
+	}
+
+	return time.Duration(e.status.Details.RetryAfterSeconds) * time.Second
+}
+
+func (e *apiError) StatusCode() string {
+	if e.status.Code == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(int(e.status.Code))
+}
+
+// apiTimeout is api error of Timeout and ServerTimeout reasons. Api does not
+// report the elapsed time, the timeout is the delay suggested by the server
+// (zero if there is none). Use faults.ClassOf rather than faults.IsTimeout
+// with the deadline to detect api timeouts reliably.
+type apiTimeout struct{ *apiError }
+
+func (e *apiTimeout) Timeout() time.Duration   { return e.RetryAfter() }
+func (e *apiTimeout) FaultClass() faults.Class { return faults.ClassTimeout }
+
+// StatusError produces Kubernetes api error equivalent to the fault.
+// The resource is used to qualify NotFound and Conflict errors.
+//
+//	return k8s.StatusError(err, schema.GroupResource{Resource: "pods"})
+func StatusError(err error, resource schema.GroupResource) *apierrors.StatusError {
+	if err == nil {
+		return nil
+	}
+
+	var status *apierrors.StatusError
+	if errors.As(err, &status) {
+		return status
+	}
+
+	switch {
+	case faults.IsNotFound(err):
+		var e faults.NotFound
+		errors.As(err, &e)
+		return apierrors.NewNotFound(resource, e.NotFound())
+	case faults.IsConflict(err), faults.IsPreConditionFailed(err):
+		return apierrors.NewConflict(resource, "", err)
+	case faults.IsGone(err):
+		return apierrors.NewGone(err.Error())
+	case faults.IsRateLimited(err), faults.IsStatusCode(err, strconv.Itoa(http.StatusTooManyRequests)):
+		after, _ := faults.RetryAfter(err)
+		return apierrors.NewTooManyRequests(err.Error(), int(after/time.Second))
+	case faults.IsTimeout(err, time.Nanosecond), faults.ClassOf(err) == faults.ClassTimeout:
+		after, _ := faults.RetryAfter(err)
+		return apierrors.NewTimeoutError(err.Error(), int(after/time.Second))
+	default:
+		return apierrors.NewInternalError(err)
+	}
+}
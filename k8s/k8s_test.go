@@ -0,0 +1,120 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package k8s_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var pods = schema.GroupResource{Resource: "pods"}
+
+func TestClassify(t *testing.T) {
+	if err := k8s.Classify(apierrors.NewNotFound(pods, "a")); !faults.IsNotFound(err, "a") {
+		t.Errorf("failed: not found %v", err)
+	}
+
+	if err := k8s.Classify(apierrors.NewConflict(pods, "a", nil)); !faults.IsConflict(err) {
+		t.Errorf("failed: conflict %v", err)
+	}
+
+	if err := k8s.Classify(apierrors.NewGone("a")); !faults.IsGone(err) {
+		t.Errorf("failed: gone %v", err)
+	}
+
+	if err := k8s.Classify(apierrors.NewTimeoutError("a", 5)); !faults.IsTimeout(err, time.Second) || faults.TotalTimeout(err) != 5*time.Second {
+		t.Errorf("failed: timeout %v", err)
+	}
+
+	if err := k8s.Classify(apierrors.NewTimeoutError("a", 0)); faults.ClassOf(err) != faults.ClassTimeout || faults.IsTimeout(err, time.Second) {
+		t.Errorf("failed: timeout without delay %v", err)
+	}
+
+	if err := k8s.StatusError(k8s.Classify(apierrors.NewTimeoutError("a", 0)), pods); !apierrors.IsTimeout(err) {
+		t.Errorf("failed: timeout without delay %v", err)
+	}
+
+	if d, ok := faults.RetryAfter(k8s.Classify(apierrors.NewTimeoutError("a", 5))); !ok || d != 5*time.Second {
+		t.Errorf("failed: timeout retry after %v", d)
+	}
+
+	if err := k8s.Classify(apierrors.NewTooManyRequestsError("a")); !faults.IsStatusCode(err, "429") || !faults.IsRateLimited(err) {
+		t.Errorf("failed: too many requests %v", err)
+	}
+
+	if d, ok := faults.RetryAfter(k8s.Classify(apierrors.NewTooManyRequests("a", 3))); !ok || d != 3*time.Second {
+		t.Errorf("failed: retry after %v", d)
+	}
+
+	err := k8s.Classify(apierrors.NewNotFound(pods, "a"))
+	if faults.IsConflict(err) || faults.IsGone(err) || faults.IsTimeout(err, 0) || faults.IsRateLimited(err) {
+		t.Errorf("failed: not found is misclassified")
+	}
+
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("failed: api error is not matchable")
+	}
+
+	cause := fmt.Errorf("other")
+	if k8s.Classify(cause) != cause {
+		t.Errorf("failed: foreign error is annotated")
+	}
+}
+
+type notFound string
+
+func (e notFound) Error() string    { return "not found" }
+func (e notFound) NotFound() string { return string(e) }
+
+type conflict string
+
+func (e conflict) Error() string  { return "conflict" }
+func (e conflict) Conflict() bool { return true }
+
+func TestStatusError(t *testing.T) {
+	const errSome = faults.Type("some")
+
+	if err := k8s.StatusError(errSome.With(notFound("a")), pods); !apierrors.IsNotFound(err) || err.ErrStatus.Details.Name != "a" {
+		t.Errorf("failed: not found %v", err)
+	}
+
+	if err := k8s.StatusError(errSome.With(conflict("a")), pods); !apierrors.IsConflict(err) {
+		t.Errorf("failed: conflict %v", err)
+	}
+
+	if err := k8s.StatusError(errSome.With(faults.ErrRateLimited(errors.New("a"), 3*time.Second)), pods); !apierrors.IsTooManyRequests(err) {
+		t.Errorf("failed: rate limited %v", err)
+	} else if d, ok := apierrors.SuggestsClientDelay(err); !ok || d != 3 {
+		t.Errorf("failed: rate limited delay %v", d)
+	}
+
+	if err := k8s.StatusError(errSome.With(faults.ErrTimeout(errors.New("a"), time.Second)), pods); !apierrors.IsTimeout(err) {
+		t.Errorf("failed: timeout %v", err)
+	}
+
+	if err := k8s.StatusError(errSome.With(errors.New("a")), pods); !apierrors.IsInternalError(err) {
+		t.Errorf("failed: internal %v", err)
+	}
+
+	status := apierrors.NewGone("a")
+	if err := k8s.StatusError(errSome.With(status), pods); err != status {
+		t.Errorf("failed: status is not preserved %v", err)
+	}
+
+	if err := k8s.StatusError(nil, pods); err != nil {
+		t.Errorf("failed: nil %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "sync"
+
+// List accumulates faults of validation or batch processing and returns
+// them as a single error, errors.Is and errors.As match each member.
+// The zero value is ready to use, it is safe for concurrent use.
+//
+//	list := faults.List{Limit: 10}
+//	for _, x := range batch {
+//		if list.Add(validate(x)) {
+//			break
+//		}
+//	}
+//	return list.Err()
+type List struct {
+	// Limit is the number of errors to collect, further errors are dropped.
+	// Zero means no limit.
+	Limit int
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// Add the error to the list, nil errors are ignored. It returns true if
+// the limit is reached, the caller is free to stop processing.
+func (l *List) Add(err error) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	full := l.Limit > 0 && len(l.errs) >= l.Limit
+	if err != nil && !full {
+		l.errs = append(l.errs, err)
+	}
+
+	return l.Limit > 0 && len(l.errs) >= l.Limit
+}
+
+// Len is the number of collected errors
+func (l *List) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.errs)
+}
+
+// Err returns collected errors joined into a single error, nil if the list
+// is empty, the error as is if it is the only one.
+func (l *List) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return joinErrs(l.errs)
+}
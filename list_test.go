@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestList(t *testing.T) {
+	const (
+		errA = errors.Fast("a")
+		errB = errors.Fast("b")
+	)
+
+	var list errors.List
+	if list.Err() != nil {
+		t.Errorf("failed: empty list")
+	}
+
+	list.Add(nil)
+	list.Add(errA.With(err))
+	if e := list.Err(); !errA.Check(e) || list.Len() != 1 || e.Error() != "a: just error" {
+		t.Errorf("failed: %v", e)
+	}
+
+	list.Add(errB.With(nil))
+	if e := list.Err(); !errA.Check(e) || !errB.Check(e) || !stderrors.Is(e, err) || e.Error() != "[a: just error; b]" {
+		t.Errorf("failed: %v", e)
+	}
+}
+
+func TestListLimit(t *testing.T) {
+	const (
+		errA = errors.Fast("a")
+		errB = errors.Fast("b")
+		errC = errors.Fast("c")
+	)
+
+	list := errors.List{Limit: 2}
+
+	if list.Add(errA.With(nil)) {
+		t.Errorf("failed: limit is not reached")
+	}
+
+	if !list.Add(errB.With(nil)) {
+		t.Errorf("failed: limit is reached")
+	}
+
+	if !list.Add(errC.With(nil)) || list.Len() != 2 || errC.Check(list.Err()) {
+		t.Errorf("failed: %v", list.Err())
+	}
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// Package lite is the tiny subset of faults for tinygo and WASM targets,
+// where binary size and reflection cost matter. It supports Fast-style
+// wrapping and classification only: no runtime.Caller, no fmt at wrap time.
+// With, Maybe and errors.Is behave as in faults.
+//
+//	const errSome = lite.Fast("something is failed")
+//
+//	return errSome.With(err)
+package lite
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Fast creates a basic context for the error, see faults.Fast
+type Fast string
+
+// With wraps error into the context.
+// The function expands the context with arguments, they are rendered lazily.
+func (e Fast) With(err error, args ...any) error {
+	return &fault{head: e, tail: err, args: args}
+}
+
+// Maybe wraps error into the context if the error is not nil, see faults.Type.Maybe
+func (e Fast) Maybe(err error, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return &fault{head: e, tail: err, args: args}
+}
+
+// WrapOnce wraps error into the context unless the error already carries
+// the context, see faults.Type.WrapOnce
+func (e Fast) WrapOnce(err error, args ...any) error {
+	if e.Check(err) {
+		return err
+	}
+
+	return &fault{head: e, tail: err, args: args}
+}
+
+// Check returns true if the error is wrapped with the context
+func (e Fast) Check(err error) bool { return errors.Is(err, e) }
+
+func (e Fast) Error() string { return string(e) }
+
+type fault struct {
+	head Fast
+	tail error
+	args []any
+}
+
+func (e *fault) Error() string {
+	msg := string(e.head)
+	if len(e.args) > 0 {
+		msg = fmt.Sprintf(msg, e.args...)
+	}
+
+	if e.tail == nil {
+		return msg
+	}
+
+	return msg + ": " + e.tail.Error()
+}
+
+func (e *fault) Unwrap() []error {
+	if e.tail == nil {
+		return []error{e.head}
+	}
+
+	return []error{e.head, e.tail}
+}
+
+// Class is a coarse-grained category of the error, values are identical
+// to faults.Class.
+type Class string
+
+const (
+	ClassNotFound           = Class("not_found")
+	ClassConflict           = Class("conflict")
+	ClassPreConditionFailed = Class("precondition_failed")
+	ClassGone               = Class("gone")
+	ClassTimeout            = Class("timeout")
+	ClassNearTimeout        = Class("near_timeout")
+	ClassDegraded           = Class("degraded")
+	ClassDown               = Class("down")
+	ClassInternal           = Class("internal")
+)
+
+type classified struct {
+	err   error
+	class Class
+}
+
+func (e classified) Error() string { return e.err.Error() }
+func (e classified) Unwrap() error { return e.err }
+
+// ErrClass assigns the class to the error, it returns nil if the error is nil.
+func ErrClass(err error, class Class) error {
+	if err == nil {
+		return nil
+	}
+
+	return classified{err: err, class: class}
+}
+
+// ClassOf returns the class of the first classified error in the chain,
+// empty if the error is not classified.
+func ClassOf(err error) Class {
+	var e classified
+	if errors.As(err, &e) {
+		return e.class
+	}
+
+	return ""
+}
@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package lite_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/lite"
+)
+
+var err = fmt.Errorf("just error")
+
+func TestFast(t *testing.T) {
+	const (
+		errA = lite.Fast("a %d")
+		errB = lite.Fast("b")
+	)
+
+	e := errA.With(errB.With(err), 1)
+
+	if e.Error() != "a 1: b: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if !errA.Check(e) || !errB.Check(e) || !errors.Is(e, err) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if errA.Maybe(nil, 1) != nil {
+		t.Errorf("failed: maybe")
+	}
+
+	if x := errB.WrapOnce(e); x != e || !errors.Is(errA.WrapOnce(err, 1), errA) {
+		t.Errorf("failed: wrap once %v", x)
+	}
+
+	if e := errB.With(nil); e.Error() != "b" || !errB.Check(e) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if lite := errA.With(errB.With(err), 1).Error(); lite != faults.Fast("a %d").With(faults.Fast("b").With(err), 1).Error() {
+		t.Errorf("failed: incompatible rendering %s", lite)
+	}
+}
+
+func TestClassOf(t *testing.T) {
+	const errA = lite.Fast("a")
+
+	e := errA.With(lite.ErrClass(err, lite.ClassDown))
+
+	if lite.ClassOf(e) != lite.ClassDown || !errors.Is(e, err) || e.Error() != "a: just error" {
+		t.Errorf("failed: %v", e)
+	}
+
+	if lite.ClassOf(err) != "" || lite.ErrClass(nil, lite.ClassDown) != nil {
+		t.Errorf("failed: unclassified")
+	}
+
+	if string(lite.ClassDown) != string(faults.ClassDown) {
+		t.Errorf("failed: incompatible classes")
+	}
+}
@@ -0,0 +1,271 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Messages is the localization catalog of faults. It maps templates declared
+// by contexts into translated templates for each language. Translations are
+// maintained by non-Go contributors as JSON files `<lang>.json`:
+//
+//	{
+//		"something %d is failed": "quelque chose %d a échoué"
+//	}
+//
+// or TOML files `<lang>.toml` of key/value pairs (tables, arrays and
+// multi-line strings are not supported):
+//
+//	# errors of the storage
+//	"something %d is failed" = "quelque chose %d a échoué"
+type Messages struct {
+	mu    sync.RWMutex
+	langs map[string]map[string]string
+}
+
+// LoadMessages loads localization catalogs from *.json and *.toml files of the
+// file system (e.g. embed.FS). The name of the file is the language.
+//
+//	//go:embed locales/*.json
+//	var locales embed.FS
+//
+//	messages, err := faults.LoadMessages(locales, "locales")
+func LoadMessages(fsys fs.FS, dir string) (*Messages, error) {
+	m := &Messages{}
+	if err := m.Reload(fsys, dir); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Reload localization catalogs from the file system. Catalogs are replaced
+// only if all files are loaded successfully.
+func (m *Messages) Reload(fsys fs.FS, dir string) error {
+	var files []string
+	for _, ext := range []string{"*.json", "*.toml"} {
+		seq, err := fs.Glob(fsys, path.Join(dir, ext))
+		if err != nil {
+			return err
+		}
+		files = append(files, seq...)
+	}
+
+	langs := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		var templates map[string]string
+		if path.Ext(file) == ".toml" {
+			templates, err = decodeTOML(b)
+		} else {
+			err = json.Unmarshal(b, &templates)
+		}
+		if err != nil {
+			return fmt.Errorf("faults: invalid catalog %s: %w", file, err)
+		}
+
+		lang := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		if _, has := langs[lang]; has {
+			return fmt.Errorf("faults: catalog %s is defined twice", lang)
+		}
+		langs[lang] = templates
+	}
+
+	m.mu.Lock()
+	m.langs = langs
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads catalogs every interval until the context is cancelled.
+// Use it in the dev mode with os.DirFS to tweak texts without restarts.
+// Failed reloads keep previous catalogs.
+func (m *Messages) Watch(ctx context.Context, fsys fs.FS, dir string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.Reload(fsys, dir)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Languages returns languages of the catalog.
+func (m *Messages) Languages() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seq := make([]string, 0, len(m.langs))
+	for lang := range m.langs {
+		seq = append(seq, lang)
+	}
+
+	return seq
+}
+
+// Localize renders the error chain using translated templates of the language.
+// Callers are not included, foreign errors and untranslated templates are
+// rendered as is. Faults wrapped by behaviors (e.g. ErrNotFound) or by
+// foreign errors (e.g. fmt.Errorf with %w) are localized too.
+func (m *Messages) Localize(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+
+	m.mu.RLock()
+	templates := m.langs[lang]
+	m.mu.RUnlock()
+
+	return localize(err, templates)
+}
+
+func localize(err error, templates map[string]string) string {
+	e, ok := err.(*errType)
+	if !ok {
+		// joined errors render messages of causes in order
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			return localizeAll(err, multi.Unwrap(), templates)
+		}
+
+		// wrappers render the message of the cause after own prefix
+		cause := errors.Unwrap(err)
+		if cause == nil {
+			return err.Error()
+		}
+
+		prefix, has := strings.CutSuffix(err.Error(), cause.Error())
+		if !has {
+			return err.Error()
+		}
+
+		return prefix + localize(cause, templates)
+	}
+
+	msg := e.Message()
+	if template, has := templates[e.head.Error()]; has {
+		if _, ok := e.head.(Named); ok {
+			msg = Named(template).render(e.args)
+		} else {
+			msg = sprintf(template, e.args)
+		}
+	}
+
+	if e.tail == nil {
+		return msg
+	}
+
+	return msg + ": " + localize(e.tail, templates)
+}
+
+// localizeAll localizes joined errors (e.g. WithAll) replacing messages
+// of causes within the message of the error.
+func localizeAll(err error, causes []error, templates map[string]string) string {
+	var sb strings.Builder
+
+	text := err.Error()
+	for _, cause := range causes {
+		before, after, has := strings.Cut(text, cause.Error())
+		if !has {
+			return err.Error()
+		}
+
+		sb.WriteString(before)
+		sb.WriteString(localize(cause, templates))
+		text = after
+	}
+	sb.WriteString(text)
+
+	return sb.String()
+}
+
+// decodeTOML decodes the catalog from the subset of TOML: key/value pairs
+// of basic or literal strings, bare keys and comments.
+func decodeTOML(b []byte) (map[string]string, error) {
+	templates := map[string]string{}
+
+	for n, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		var (
+			key, val string
+			ok       bool
+		)
+
+		if line[0] == '"' || line[0] == '\'' {
+			key, line, ok = tomlString(line)
+		} else {
+			key, line, ok = strings.Cut(line, "=")
+			key, line = strings.TrimSpace(key), "="+line
+			ok = ok && key != "" && strings.Trim(key, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-") == ""
+		}
+
+		line, eq := strings.CutPrefix(strings.TrimSpace(line), "=")
+		if ok && eq {
+			val, line, ok = tomlString(strings.TrimSpace(line))
+		}
+
+		line = strings.TrimSpace(line)
+		if !ok || !eq || (line != "" && line[0] != '#') {
+			return nil, fmt.Errorf("line %d: invalid key/value pair", n+1)
+		}
+
+		if _, has := templates[key]; has {
+			return nil, fmt.Errorf("line %d: key %q is defined twice", n+1, key)
+		}
+		templates[key] = val
+	}
+
+	return templates, nil
+}
+
+// tomlString parses the basic ("...") or literal ('...') string at the head
+// of the line, it returns the rest of the line.
+func tomlString(s string) (string, string, bool) {
+	switch {
+	case strings.HasPrefix(s, "'"):
+		val, rest, ok := strings.Cut(s[1:], "'")
+		return val, rest, ok
+	case strings.HasPrefix(s, `"`):
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				val, err := strconv.Unquote(s[:i+1])
+				return val, s[i+1:], err == nil
+			}
+		}
+	}
+
+	return "", s, false
+}
@@ -0,0 +1,142 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestMessages(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Safe1[string]("b %s")
+	)
+
+	fsys := fstest.MapFS{
+		"locales/fr.json": {Data: []byte(`{"a %d": "à %d", "b %s": "bé %s", "c": "cé"}`)},
+		"locales/de.json": {Data: []byte(`{"a %d": "ä %d"}`)},
+	}
+
+	messages, e := errors.LoadMessages(fsys, "locales")
+	if e != nil {
+		t.Fatalf("failed: %v", e)
+	}
+
+	if len(messages.Languages()) != 2 {
+		t.Errorf("failed: %v", messages.Languages())
+	}
+
+	x := errA.With(errB.With(err, "x"), 1)
+
+	for lang, expect := range map[string]string{
+		"fr": "à 1: bé x: just error",
+		"de": "ä 1: b x: just error",
+		"en": "a 1: b x: just error",
+	} {
+		if msg := messages.Localize(x, lang); msg != expect {
+			t.Errorf("failed: %s", msg)
+		}
+	}
+
+	y := errA.With(fmt.Errorf("c: %w", errors.ErrGone(errB.With(err, "x"))), 1)
+	if msg := messages.Localize(y, "fr"); msg != "à 1: c: bé x: just error" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	z := errA.With(errors.Fast("c").WithAll(errB.With(err, "x"), stderrors.Join(err, errB.With(err, "y"))), 1)
+	if msg := messages.Localize(z, "fr"); msg != "à 1: cé: [bé x: just error; just error\nbé y: just error]" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	if messages.Localize(nil, "fr") != "" {
+		t.Errorf("failed: nil")
+	}
+
+	if _, e := errors.LoadMessages(fstest.MapFS{"x.json": {Data: []byte(`[`)}}, "."); e == nil {
+		t.Errorf("failed: invalid catalog")
+	}
+}
+
+func TestMessagesTOML(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Fast("b")
+	)
+
+	fsys := fstest.MapFS{
+		"fr.toml": {Data: []byte(`
+# faults of the service
+"a %d" = "à %d" # inline comment
+b = 'bé "x"'
+`)},
+	}
+
+	messages, e := errors.LoadMessages(fsys, ".")
+	if e != nil {
+		t.Fatalf("failed: %v", e)
+	}
+
+	if msg := messages.Localize(errA.With(errB.With(err), 1), "fr"); msg != `à 1: bé "x": just error` {
+		t.Errorf("failed: %s", msg)
+	}
+
+	for _, invalid := range []string{
+		`"a" "b"`,
+		`a = b`,
+		`a b = "c"`,
+		`"a = "b"`,
+		`a = "b" c`,
+		"a = \"b\"\na = \"c\"",
+		`[table]`,
+	} {
+		if _, e := errors.LoadMessages(fstest.MapFS{"fr.toml": {Data: []byte(invalid)}}, "."); e == nil {
+			t.Errorf("failed: invalid catalog %s", invalid)
+		}
+	}
+
+	twice := fstest.MapFS{
+		"fr.toml": {Data: []byte(`a = "à"`)},
+		"fr.json": {Data: []byte(`{"a": "à"}`)},
+	}
+	if _, e := errors.LoadMessages(twice, "."); e == nil {
+		t.Errorf("failed: catalog defined twice")
+	}
+}
+
+func TestMessagesWatch(t *testing.T) {
+	const errA = errors.Fast("a")
+
+	fsys := fstest.MapFS{"fr.json": {Data: []byte(`{"a": "à"}`)}}
+
+	messages, e := errors.LoadMessages(fsys, ".")
+	if e != nil {
+		t.Fatalf("failed: %v", e)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages.Watch(ctx, fstest.MapFS{"fr.json": {Data: []byte(`{"a": "ah"}`)}}, ".", time.Millisecond)
+
+	for i := 0; i < 1000; i++ {
+		if messages.Localize(errA.With(nil), "fr") == "ah" {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Errorf("failed: catalog is not reloaded")
+}
@@ -0,0 +1,107 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"encoding/json"
+	"io"
+	"sync/atomic"
+)
+
+// Mapping of the class to HTTP status, process exit code (sysexits),
+// severity and retryability used at system edges. Severity and retryability
+// are defaults of SeverityOf and IsRetryable.
+type Mapping struct {
+	HTTP      int           `json:"http"`
+	Exit      int           `json:"exit"`
+	Severity  SeverityLevel `json:"severity"`
+	Retryable bool          `json:"retryable"`
+}
+
+var mappings atomic.Pointer[map[Class]Mapping]
+
+func init() {
+	mappings.Store(&map[Class]Mapping{
+		ClassNotFound:           {HTTP: 404, Exit: 66, Severity: SeverityInfo},
+		ClassConflict:           {HTTP: 409, Exit: 65, Severity: SeverityInfo},
+		ClassPreConditionFailed: {HTTP: 412, Exit: 65, Severity: SeverityInfo},
+		ClassGone:               {HTTP: 410, Exit: 66, Severity: SeverityInfo},
+		ClassTimeout:            {HTTP: 504, Exit: 75, Severity: SeverityError, Retryable: true},
+		ClassNearTimeout:        {HTTP: 200, Exit: 0, Severity: SeverityWarn},
+		ClassDegraded:           {HTTP: 503, Exit: 75, Severity: SeverityWarn, Retryable: true},
+		ClassDown:               {HTTP: 503, Exit: 69, Severity: SeverityError, Retryable: true},
+		ClassInternal:           {HTTP: 500, Exit: 70, Severity: SeverityError},
+	})
+}
+
+// LoadMappings overrides mappings of classes from JSON config at startup,
+// so ops adjust mappings without code changes. Attributes not given in
+// the config are preserved, unknown classes are added.
+//
+//	{
+//		"conflict": {"retryable": true},
+//		"gone": {"http": 404}
+//	}
+func LoadMappings(r io.Reader) error {
+	var overrides map[Class]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&overrides); err != nil {
+		return err
+	}
+
+	current := *mappings.Load()
+	updated := make(map[Class]Mapping, len(current)+len(overrides))
+	for class, m := range current {
+		updated[class] = m
+	}
+
+	for class, raw := range overrides {
+		m := updated[class]
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		updated[class] = m
+	}
+
+	mappings.Store(&updated)
+	generation.Add(1)
+	return nil
+}
+
+// MappingOf returns the mapping of the error class, unclassified errors
+// are mapped as ClassInternal. The zero mapping is returned for nil error.
+//
+//	http.Error(w, err.Error(), faults.MappingOf(err).HTTP)
+func MappingOf(err error) Mapping {
+	if err == nil {
+		return Mapping{}
+	}
+
+	class := ClassOf(err)
+	if class == "" {
+		class = ClassInternal
+	}
+
+	return mappingOf(class)
+}
+
+// mapped returns true if the class has the mapping
+func mapped(class Class) bool {
+	_, has := (*mappings.Load())[class]
+	return has
+}
+
+// mappingOf the class, unknown classes are mapped as ClassInternal
+func mappingOf(class Class) Mapping {
+	m := *mappings.Load()
+	if x, has := m[class]; has {
+		return x
+	}
+
+	return m[ClassInternal]
+}
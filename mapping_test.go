@@ -0,0 +1,73 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestMappingOf(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		http int
+	}{
+		{errors.ErrNotFound(err, "k"), 404},
+		{errors.ErrConflict(err), 409},
+		{errors.ErrDown(err), 503},
+		{err, 500},
+		{errors.ErrClass(err, "custom"), 500},
+	} {
+		if m := errors.MappingOf(tt.err); m.HTTP != tt.http {
+			t.Errorf("failed: %v %+v", tt.err, m)
+		}
+	}
+
+	if m := errors.MappingOf(nil); m != (errors.Mapping{}) {
+		t.Errorf("failed: %+v", m)
+	}
+}
+
+func TestLoadMappings(t *testing.T) {
+	conflict := errors.ErrConflict(err)
+	custom := errors.ErrClass(err, "custom")
+
+	fault := errors.Type("a").With(conflict)
+
+	if errors.MappingOf(conflict).Retryable || errors.IsRetryable(conflict) || errors.Inspect(fault).Retryable {
+		t.Errorf("failed: conflict is not retryable by default")
+	}
+
+	err := errors.LoadMappings(strings.NewReader(`{
+		"conflict": {"retryable": true},
+		"custom": {"http": 418, "severity": "warn"}
+	}`))
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+	defer errors.LoadMappings(strings.NewReader(`{"conflict": {"retryable": false}}`))
+
+	if m := errors.MappingOf(conflict); !m.Retryable || m.HTTP != 409 || m.Exit != 65 {
+		t.Errorf("failed: %+v", m)
+	}
+
+	if !errors.IsRetryable(conflict) || !errors.Inspect(fault).Retryable {
+		t.Errorf("failed: conflict is retryable by mapping")
+	}
+
+	if m := errors.MappingOf(custom); m.HTTP != 418 || m.Severity != errors.SeverityWarn || errors.SeverityOf(custom) != errors.SeverityWarn {
+		t.Errorf("failed: %+v", m)
+	}
+
+	if err := errors.LoadMappings(strings.NewReader(`{"conflict": 1}`)); err == nil {
+		t.Errorf("failed: invalid config")
+	}
+}
@@ -0,0 +1,134 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"errors"
+	"strings"
+)
+
+// Predicate is the composable matcher of errors. It returns its name and
+// true if it fires, the name is returned even if the predicate does not fire.
+type Predicate func(err error) (string, bool)
+
+// Match tests the error against the predicate and returns the name of fired
+// one, it lets policy code express decisions declaratively.
+//
+//	retry := faults.AnyOf(faults.TimeoutP(), faults.RateLimitedP())
+//
+//	if reason, ok := faults.Match(err, retry); ok {
+//		slog.Info("retrying", "reason", reason)
+//	}
+func Match(err error, p Predicate) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+
+	name, ok := p(err)
+	if !ok {
+		return "", false
+	}
+	return name, true
+}
+
+// P creates the named predicate from the test function.
+//
+//	faults.P("throttled", func(err error) bool { return faults.IsStatus(err, 429) })
+func P(name string, test func(error) bool) Predicate {
+	return func(err error) (string, bool) { return name, test(err) }
+}
+
+// AnyOf fires if any of predicates fires, the name of the first fired one
+// is returned.
+func AnyOf(ps ...Predicate) Predicate {
+	return func(err error) (string, bool) {
+		names := make([]string, 0, len(ps))
+		for _, p := range ps {
+			name, ok := p(err)
+			if ok {
+				return name, true
+			}
+			names = append(names, name)
+		}
+		return strings.Join(names, " or "), false
+	}
+}
+
+// AllOf fires if all of predicates fire, names are joined by "and".
+func AllOf(ps ...Predicate) Predicate {
+	return func(err error) (string, bool) {
+		names := make([]string, 0, len(ps))
+		fired := true
+		for _, p := range ps {
+			name, ok := p(err)
+			fired = fired && ok
+			names = append(names, name)
+		}
+		return strings.Join(names, " and "), fired
+	}
+}
+
+// Not fires if the predicate does not fire.
+func Not(p Predicate) Predicate {
+	return func(err error) (string, bool) {
+		name, ok := p(err)
+		return "not " + name, !ok
+	}
+}
+
+// IsP fires if the error matches the target using errors.Is, the name is
+// the message of the target.
+func IsP(target error) Predicate {
+	return P(target.Error(), func(err error) bool { return errors.Is(err, target) })
+}
+
+// ClassP fires if the error is of the class, see ClassOf.
+func ClassP(class Class) Predicate {
+	return P(string(class), func(err error) bool { return ClassOf(err) == class })
+}
+
+// NotFoundP fires on NotFound behavior, see IsNotFound.
+func NotFoundP() Predicate {
+	return P("not_found", func(err error) bool { return IsNotFound(err) })
+}
+
+// ConflictP fires on Conflict behavior, see IsConflict.
+func ConflictP() Predicate { return P("conflict", IsConflict) }
+
+// PreConditionFailedP fires on PreConditionFailed behavior, see IsPreConditionFailed.
+func PreConditionFailedP() Predicate { return P("precondition_failed", IsPreConditionFailed) }
+
+// GoneP fires on Gone behavior, see IsGone.
+func GoneP() Predicate { return P("gone", IsGone) }
+
+// TimeoutP fires on Timeout behavior of any duration, see IsTimeout.
+func TimeoutP() Predicate {
+	return P("timeout", func(err error) bool {
+		e, ok := Extract[Timeout](err)
+		return ok && e.Timeout() > 0
+	})
+}
+
+// DegradedP fires on Degraded behavior, see IsDegraded.
+func DegradedP() Predicate { return P("degraded", IsDegraded) }
+
+// DownP fires on Down behavior, see IsDown.
+func DownP() Predicate { return P("down", IsDown) }
+
+// RateLimitedP fires on RateLimited behavior, see IsRateLimited.
+func RateLimitedP() Predicate { return P("rate_limited", IsRateLimited) }
+
+// RetryableP fires on Retryable behavior, see IsRetryable.
+func RetryableP() Predicate { return P("retryable", IsRetryable) }
+
+// TemporaryP fires on Temporary behavior, see IsTemporary.
+func TemporaryP() Predicate { return P("temporary", IsTemporary) }
+
+// TransientP fires on transient errors, see IsTransient.
+func TransientP() Predicate { return P("transient", IsTransient) }
@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestMatch(t *testing.T) {
+	const errA = errors.Type("a")
+
+	retry := errors.AnyOf(errors.TimeoutP(), errors.RateLimitedP())
+	fail := errors.AllOf(errors.ConflictP(), errors.Not(errors.RetryableP()))
+
+	for _, tt := range []struct {
+		err    error
+		p      errors.Predicate
+		name   string
+		expect bool
+	}{
+		{errA.With(errors.ErrTimeout(err, time.Second)), retry, "timeout", true},
+		{errA.With(errors.ErrRateLimited(err, time.Second)), retry, "rate_limited", true},
+		{errA.With(errors.ErrConflict(err)), retry, "", false},
+		{errA.With(errors.ErrConflict(err)), fail, "conflict and not retryable", true},
+		{errA.With(errors.ErrRetryable(errors.ErrConflict(err))), fail, "", false},
+		{errA.With(errors.ErrNotFound(err, "k")), errors.NotFoundP(), "not_found", true},
+		{errA.With(err), errors.IsP(errA), "a", true},
+		{errA.With(errors.ErrDown(err)), errors.ClassP(errors.ClassDown), "down", true},
+		{errA.With(err), errors.P("custom", func(error) bool { return true }), "custom", true},
+		{nil, errors.P("custom", func(error) bool { return true }), "", false},
+	} {
+		if name, ok := errors.Match(tt.err, tt.p); name != tt.name || ok != tt.expect {
+			t.Errorf("failed: %v is %q %v", tt.err, name, ok)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+// ErrMust is the context of errors raised by Must
+const ErrMust = Type("must not fail")
+
+// Must returns the value if the error is nil, otherwise it panics with
+// the error wrapped into ErrMust. Use it for initialization-time code.
+//
+//	var tmpl = faults.Must(template.New("x").Parse(text))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(withCaller(0, newErrType(ErrMust, err, nil)))
+	}
+
+	return v
+}
@@ -0,0 +1,76 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func mustPanic(t *testing.T, f func()) (fault errors.Fault) {
+	t.Helper()
+
+	defer func() {
+		r := recover()
+		e, ok := r.(error)
+		if !ok || !stderrors.As(e, &fault) {
+			t.Errorf("failed: unexpected panic %v", r)
+		}
+	}()
+
+	f()
+	return
+}
+
+func TestMust(t *testing.T) {
+	if v := errors.Must(10, nil); v != 10 {
+		t.Errorf("failed: %v", v)
+	}
+
+	fault := mustPanic(t, func() { errors.Must(10, err) })
+	if !errors.ErrMust.Check(fault) || !stderrors.Is(fault, err) {
+		t.Errorf("failed: %v", fault)
+	}
+
+	if name, _ := fault.Caller(); withCallers && name != "github.com/fogfish/faults_test.TestMust.func1" {
+		t.Errorf("failed: %s", name)
+	}
+}
+
+func TestTypeMust(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+		errC = errors.Deep("c")
+	)
+
+	errA.Must(nil)
+	errB.Must(nil)
+	errC.Must(nil)
+
+	fault := mustPanic(t, func() { errA.Must(err) })
+	if !errA.Check(fault) || !stderrors.Is(fault, err) {
+		t.Errorf("failed: %v", fault)
+	}
+
+	if name, _ := fault.Caller(); withCallers && name != "github.com/fogfish/faults_test.TestTypeMust.func1" {
+		t.Errorf("failed: %s", name)
+	}
+
+	if fault := mustPanic(t, func() { errB.Must(err) }); !errB.Check(fault) {
+		t.Errorf("failed: %v", fault)
+	}
+
+	fault = mustPanic(t, func() { errC.Must(err) })
+	if name, _ := fault.Caller(); withCallers && name != "github.com/fogfish/faults_test.TestTypeMust.func3" {
+		t.Errorf("failed: %s", name)
+	}
+}
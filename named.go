@@ -0,0 +1,128 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Named creates a context for the error whose template uses `{key}`
+// placeholders instead of positional verbs. Arguments are passed as
+// key-value pairs, fields or a map, they are also fields of the fault.
+//
+//	const errSome = faults.Named("unable to read {key} from {bucket}")
+//
+//	errSome.With(err, "bucket", b, "key", k)
+type Named string
+
+// With wraps error into the context.
+// The function expands the context with named arguments.
+//
+//	if err := doSomething(); err != nil {
+//		return nil, errSome.With(err, "bucket", b, "key", k)
+//	}
+func (e Named) With(err error, args ...any) error {
+	return withCaller(0, e.fault(err, args))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (e Named) Maybe(err error, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return withCaller(0, e.fault(err, args))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (e Named) WrapOnce(err error, args ...any) error {
+	if carries(err, e) {
+		return err
+	}
+
+	return withCaller(0, e.fault(err, args))
+}
+
+// fault builds unsealed fault with named arguments
+func (e Named) fault(err error, args []any) *errType {
+	fields := namedFields(args)
+	pairs := make([]any, 0, 2*len(fields))
+	for _, field := range fields {
+		pairs = append(pairs, field.Key, field.Value)
+	}
+
+	x := newFault(e, err, pairs)
+	x.fields = fields
+	return x
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (e Named) Check(err error) bool { return errors.Is(err, e) }
+
+func (e Named) Error() string { return string(e) }
+func (e Named) arity() int    { return -1 }
+func (e Named) zero() string  { return string(e) }
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (e Named) MatchAlso(errs ...error) Named {
+	matchAlso(e, errs)
+	return e
+}
+
+// render substitutes placeholders with values of named arguments, unknown
+// placeholders are kept as is.
+func (e Named) render(args []any) string {
+	if len(args) == 0 {
+		return string(e)
+	}
+
+	max := cfg.Load().maxArgLength
+
+	kv := make([]string, 0, len(args))
+	for i := 0; i+1 < len(args); i += 2 {
+		val := args[i+1]
+		if max > 0 {
+			val = truncateArgs([]any{val}, max)[0]
+		}
+		kv = append(kv, "{"+fmt.Sprint(args[i])+"}", fmt.Sprint(val))
+	}
+
+	return strings.NewReplacer(kv...).Replace(string(e))
+}
+
+// namedFields normalizes key-value pairs, fields and maps into fields
+func namedFields(args []any) []Field {
+	var fields []Field
+
+	for i := 0; i < len(args); i++ {
+		switch v := args[i].(type) {
+		case Field:
+			fields = append(fields, v)
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fields = append(fields, Field{Key: key, Value: v[key]})
+			}
+		case string:
+			if i+1 < len(args) {
+				fields = append(fields, Field{Key: v, Value: args[i+1]})
+				i++
+			}
+		}
+	}
+
+	return fields
+}
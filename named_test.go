@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestNamed(t *testing.T) {
+	const errA = errors.Named("unable to read {key} from {bucket}, {unknown}")
+
+	for expect, e := range map[string]error{
+		"unable to read k from b, {unknown}":            errA.With(err, "bucket", "b", "key", "k"),
+		"unable to read k from 1, {unknown}":            errA.With(err, errors.F("bucket", 1), errors.F("key", "k")),
+		"unable to read k from b, x":                    errA.With(err, map[string]any{"bucket": "b", "key": "k", "unknown": "x"}),
+		"unable to read {key} from {bucket}, {unknown}": errA.With(err),
+	} {
+		var fault errors.Fault
+		if !stderrors.As(e, &fault) || fault.Message() != expect {
+			t.Errorf("failed: %v", e)
+		}
+
+		if !errA.Check(e) || !stderrors.Is(e, err) {
+			t.Errorf("failed: %v", e)
+		}
+	}
+
+	e := errA.With(err, "bucket", "b", "key", "k")
+	if !strings.HasPrefix(e.Error(), at("TestNamed", 38)+"unable to read k from b") {
+		t.Errorf("failed: %s", e)
+	}
+
+	if fields := errors.Fields(e); len(fields) != 2 || fields[0] != errors.F("bucket", "b") {
+		t.Errorf("failed: %v", fields)
+	}
+
+	if errA.Maybe(nil, "bucket", "b") != nil {
+		t.Errorf("failed: maybe")
+	}
+
+	errors.Catalog{errA}.SelfTest(t)
+}
@@ -0,0 +1,15 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build faults_nocaller
+
+package faults
+
+// captureCallers is turned off by the build tag faults_nocaller, all
+// contexts behave as Fast.
+const captureCallers = false
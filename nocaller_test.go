@@ -0,0 +1,40 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build faults_nocaller
+
+package faults_test
+
+import (
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+// withCallers is false if the suite is built with faults_nocaller
+const withCallers = false
+
+// at renders the caller annotation of the fault, it is empty without callers
+func at(string, int) string { return "" }
+
+// requireCallers skips the test that asserts callers of faults
+func requireCallers(t *testing.T) {
+	t.Helper()
+	t.Skip("callers are not captured, see faults_nocaller")
+}
+
+func TestNoCaller(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Safe1[int]("b %d")
+	)
+
+	if e := errA.With(errB.With(err, 1)); e.Error() != "a: b 1: just error" {
+		t.Errorf("failed: %v", e)
+	}
+}
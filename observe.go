@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// created is invoked once the fault is sealed. It records the creation
+// time and the build if they are enabled and notifies observers.
+func created(e *errType) *errType {
+	c := cfg.Load()
+
+	if c.timestamps {
+		e.at = c.clock()
+	}
+
+	if c.build != nil {
+		e.build = c.build
+	}
+
+	for _, observer := range c.observers {
+		observer(e)
+	}
+
+	return e
+}
+
+// Observe registers observers notified synchronously about every fault
+// created by contexts. Observers are appended to already registered ones,
+// the call without observers removes all of them. Keep observers fast, they are on the error path.
+//
+//	faults.Configure(faults.Observe(func(f faults.Fault) { ... }))
+func Observe(observers ...func(Fault)) Option {
+	return func(c *config) {
+		if len(observers) == 0 {
+			c.observers = nil
+			return
+		}
+		c.observers = append(slices.Clip(c.observers), observers...)
+	}
+}
+
+// AuditRecord is the traceable record of the failure in the audited domain
+type AuditRecord struct {
+	Ref      FaultRef
+	Template string
+	Caller   string
+	Args     []string
+	Time     time.Time
+}
+
+// Auditor records faults created by declarations of designated domains
+// (e.g. auth, billing) into the dedicated audit sink. Arguments are redacted.
+//
+//	auditor := faults.Auditor{
+//		Catalog: faults.Catalog{errLoginFailed, errChargeDeclined},
+//		Sink:    func(r faults.AuditRecord) { audit.Write(r) },
+//	}
+//
+//	faults.Configure(faults.Observe(auditor.Observe))
+type Auditor struct {
+	// Catalog is the list of audited declarations
+	Catalog Catalog
+
+	// Sink receives audit records
+	Sink func(AuditRecord)
+
+	// Redact renders arguments, all arguments are "[REDACTED]" if it is nil
+	Redact func(arg any) string
+}
+
+// Observe is the observer of faults, see faults.Observe
+func (a Auditor) Observe(f Fault) {
+	e, ok := f.(*errType)
+	if !ok || !slices.Contains(a.Catalog, e.head) {
+		return
+	}
+
+	args := make([]string, len(e.args))
+	for i, arg := range e.args {
+		if a.Redact != nil {
+			args[i] = a.Redact(arg)
+		} else {
+			args[i] = "[REDACTED]"
+		}
+	}
+
+	var caller string
+	if name, line := e.location(); name != "" {
+		caller = fmt.Sprintf("%s:%d", name, line)
+	}
+
+	at := e.at
+	if at.IsZero() {
+		at = cfg.Load().clock()
+	}
+
+	a.Sink(AuditRecord{
+		Ref:      Ref(e),
+		Template: e.head.Error(),
+		Caller:   caller,
+		Args:     args,
+		Time:     at,
+	})
+}
@@ -0,0 +1,69 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestObserve(t *testing.T) {
+	const errA = errors.Fast("a")
+
+	var seq []string
+	errors.Configure(errors.Observe(func(f errors.Fault) { seq = append(seq, f.Message()) }))
+	defer errors.Configure(errors.Observe())
+
+	errA.With(nil)
+	errors.Safe1[int]("b %d").With(nil, 1)
+
+	if len(seq) != 2 || seq[0] != "a" || seq[1] != "b 1" {
+		t.Errorf("failed: %v", seq)
+	}
+}
+
+func TestAuditor(t *testing.T) {
+	var (
+		errLogin  = errors.Type("login of %s is failed")
+		errCharge = errors.Code("B1", "charge %d is declined")
+		errOther  = errors.Type("other %s")
+	)
+
+	var seq []errors.AuditRecord
+	auditor := errors.Auditor{
+		Catalog: errors.Catalog{errLogin, errCharge},
+		Sink:    func(r errors.AuditRecord) { seq = append(seq, r) },
+	}
+
+	errors.Configure(errors.Observe(auditor.Observe))
+	defer errors.Configure(errors.Observe())
+
+	e := errLogin.With(err, "alice")
+	errOther.With(err, "x")
+
+	auditor.Redact = func(arg any) string { return fmt.Sprintf("%T", arg) }
+	errors.Configure(errors.Observe(), errors.Observe(auditor.Observe))
+	errCharge.With(nil, 100)
+
+	if len(seq) != 2 {
+		t.Fatalf("failed: %v", seq)
+	}
+
+	if r := seq[0]; r.Ref != errors.Ref(e) || r.Template != "login of %s is failed" ||
+		(withCallers && r.Caller != "github.com/fogfish/faults_test.TestAuditor:49") ||
+		len(r.Args) != 1 || r.Args[0] != "[REDACTED]" || r.Time.IsZero() {
+		t.Errorf("failed: %+v", r)
+	}
+
+	if r := seq[1]; r.Ref.Code != "B1" || r.Args[0] != "int" {
+		t.Errorf("failed: %+v", r)
+	}
+}
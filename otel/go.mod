@@ -0,0 +1,12 @@
+module github.com/fogfish/faults/otel
+
+go 1.22
+
+require (
+	github.com/fogfish/faults v0.0.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+replace github.com/fogfish/faults => ../
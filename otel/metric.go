@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package otel
+
+import (
+	"context"
+
+	"github.com/fogfish/faults"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Counter counts faults by class and code. Faults are recorded within
+// the context of the request, so the metrics SDK attaches the current trace
+// to the counter as an exemplar, linking error-rate spikes to example traces.
+//
+//	counter, err := otel.NewCounter(provider.Meter("service"))
+//
+//	if err := doSomething(ctx); err != nil {
+//		counter.Add(ctx, err)
+//	}
+type Counter struct {
+	counter metric.Int64Counter
+}
+
+// NewCounter creates the counter of faults using the meter.
+func NewCounter(meter metric.Meter) (*Counter, error) {
+	counter, err := meter.Int64Counter("faults",
+		metric.WithDescription("number of faults by class and code"),
+		metric.WithUnit("{fault}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Counter{counter: counter}, nil
+}
+
+// Add the fault to the counter, nil errors are not counted. Unclassified
+// errors are counted as faults.ClassInternal.
+func (c *Counter) Add(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	class := faults.ClassOf(err)
+	if class == "" {
+		class = faults.ClassInternal
+	}
+
+	attrs := []attribute.KeyValue{attribute.String(KeyClass, string(class))}
+	if code := faults.CodeOf(err); code != "" {
+		attrs = append(attrs, attribute.String(KeyCode, code))
+	}
+
+	c.counter.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+type sample struct {
+	ctx   context.Context
+	value int64
+	attrs attribute.Set
+}
+
+type meter struct {
+	noop.Meter
+	samples *[]sample
+}
+
+func (m meter) Int64Counter(string, ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return counter{samples: m.samples}, nil
+}
+
+type counter struct {
+	noop.Int64Counter
+	samples *[]sample
+}
+
+func (c counter) Add(ctx context.Context, value int64, opts ...metric.AddOption) {
+	*c.samples = append(*c.samples, sample{ctx, value, metric.NewAddConfig(opts).Attributes()})
+}
+
+type ctxKey struct{}
+
+func TestCounter(t *testing.T) {
+	errA := faults.Code("E1", "a")
+
+	var samples []sample
+	c, err := otel.NewCounter(meter{samples: &samples})
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+	c.Add(ctx, faults.ErrGone(errA.With(nil)))
+	c.Add(ctx, errors.New("b"))
+	c.Add(ctx, nil)
+
+	if len(samples) != 2 || samples[0].value != 1 || samples[0].ctx.Value(ctxKey{}) != "request" {
+		t.Fatalf("failed: %v", samples)
+	}
+
+	if v, _ := samples[0].attrs.Value(otel.KeyClass); v.AsString() != "gone" {
+		t.Errorf("failed: class %v", v)
+	}
+
+	if v, _ := samples[0].attrs.Value(otel.KeyCode); v.AsString() != "E1" {
+		t.Errorf("failed: code %v", v)
+	}
+
+	if v, _ := samples[1].attrs.Value(otel.KeyClass); v.AsString() != "internal" || samples[1].attrs.Len() != 1 {
+		t.Errorf("failed: internal %v", samples[1].attrs)
+	}
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+// Package otel writes classification of faults into OpenTelemetry baggage,
+// so that downstream services see it even if the error does not propagate
+// as a value (e.g. fire-and-forget flows). Faults are linked to traces and
+// counted as metrics with trace exemplars, see TraceID and Counter.
+package otel
+
+import (
+	"context"
+
+	"github.com/fogfish/faults"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// Baggage keys
+const (
+	KeyClass = "fault.class"
+	KeyCode  = "fault.code"
+)
+
+// WithBaggage writes class and code of the error into the baggage of
+// the context. Unclassified errors are written as faults.ClassInternal.
+//
+//	ctx, err = otel.WithBaggage(ctx, err)
+func WithBaggage(ctx context.Context, err error) (context.Context, error) {
+	if err == nil {
+		return ctx, nil
+	}
+
+	class := faults.ClassOf(err)
+	if class == "" {
+		class = faults.ClassInternal
+	}
+
+	bag := baggage.FromContext(ctx)
+
+	member, merr := baggage.NewMember(KeyClass, string(class))
+	if merr != nil {
+		return ctx, merr
+	}
+	if bag, merr = bag.SetMember(member); merr != nil {
+		return ctx, merr
+	}
+
+	if code := faults.CodeOf(err); code != "" {
+		member, merr := baggage.NewMemberRaw(KeyCode, code)
+		if merr != nil {
+			return ctx, merr
+		}
+		if bag, merr = bag.SetMember(member); merr != nil {
+			return ctx, merr
+		}
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+// ClassOf reads class of the error from the baggage of the context.
+func ClassOf(ctx context.Context) faults.Class {
+	return faults.Class(baggage.FromContext(ctx).Member(KeyClass).Value())
+}
+
+// CodeOf reads code of the error from the baggage of the context.
+func CodeOf(ctx context.Context) string {
+	return baggage.FromContext(ctx).Member(KeyCode).Value()
+}
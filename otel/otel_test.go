@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/otel"
+)
+
+func TestWithBaggage(t *testing.T) {
+	const errA = faults.Type("a")
+
+	ctx, err := otel.WithBaggage(context.Background(), errA.With(faults.ErrDown(errors.New("b"))))
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	if c := otel.ClassOf(ctx); c != faults.ClassDown {
+		t.Errorf("failed: %s", c)
+	}
+
+	if c := otel.CodeOf(ctx); c != "" {
+		t.Errorf("failed: %s", c)
+	}
+
+	const errB = faults.Coded("E1: b")
+
+	ctx, _ = otel.WithBaggage(context.Background(), faults.ErrNotFound(errA.With(errB.With(nil)), "k"))
+	if c := otel.CodeOf(ctx); c != "E1" || otel.ClassOf(ctx) != faults.ClassNotFound {
+		t.Errorf("failed: %s", c)
+	}
+
+	ctx, _ = otel.WithBaggage(ctx, errors.New("b"))
+	if c := otel.ClassOf(ctx); c != faults.ClassInternal {
+		t.Errorf("failed: %s", c)
+	}
+
+	ctx, _ = otel.WithBaggage(context.Background(), nil)
+	if c := otel.ClassOf(ctx); c != "" {
+		t.Errorf("failed: %s", c)
+	}
+}
@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package otel
+
+import (
+	"context"
+
+	"github.com/fogfish/faults"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// KeyTraceID is the field of the fault holding the trace id
+const KeyTraceID = "trace.id"
+
+// TraceID returns the field attaching id of the current trace to the fault,
+// the field is omitted if the context has no trace id. Metrics backends
+// use it as an exemplar linking error-rate spikes to example traces.
+//
+//	errSome.With(err, otel.TraceID(ctx))
+func TraceID(ctx context.Context) faults.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return faults.Field{}
+	}
+
+	return faults.F(KeyTraceID, sc.TraceID().String())
+}
+
+// TraceOf returns the trace id attached to the first fault in the chain.
+//
+//	counter.(prometheus.ExemplarAdder).AddWithExemplar(1,
+//		prometheus.Labels{"trace_id": otel.TraceOf(err)},
+//	)
+func TraceOf(err error) string {
+	for _, field := range faults.Fields(err) {
+		if id, ok := field.Value.(string); ok && field.Key == KeyTraceID && id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
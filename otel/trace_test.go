@@ -0,0 +1,37 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package otel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fogfish/faults"
+	"github.com/fogfish/faults/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceID(t *testing.T) {
+	const errA = faults.Type("a")
+
+	id, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	sid, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: id, SpanID: sid}),
+	)
+
+	err := errA.With(errA.With(nil, otel.TraceID(context.Background())), otel.TraceID(ctx))
+	if otel.TraceOf(err) != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("failed: %s", otel.TraceOf(err))
+	}
+
+	if e := errA.With(nil, otel.TraceID(context.Background())); otel.TraceOf(e) != "" || len(faults.Fields(e)) != 0 {
+		t.Errorf("failed: empty trace %v", faults.Fields(e))
+	}
+}
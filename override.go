@@ -0,0 +1,108 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "sync"
+
+// ErrOverrideDisabled is the error returned by Override unless overrides
+// are permitted by Configure.
+const ErrOverrideDisabled = Fast("message overrides are disabled, see faults.Overrides")
+
+var (
+	muOverrides sync.RWMutex
+	overrides   []override
+)
+
+type override struct {
+	head error
+	text string
+}
+
+// Override replaces the public message of the context at runtime, e.g.
+// operators add a status-page link during an incident without redeploying.
+// Templates and Error() stay stable, only PublicMessage is affected.
+// The empty text removes the override.
+//
+//	faults.Configure(faults.Overrides(true))
+//	faults.Override(errSome, "service is degraded, see https://status.example.com")
+func Override(errX error, text string) error {
+	if !cfg.Load().overrides {
+		return ErrOverrideDisabled.With(nil)
+	}
+
+	muOverrides.Lock()
+	defer muOverrides.Unlock()
+
+	for i := range overrides {
+		if overrides[i].head == errX {
+			if text == "" {
+				overrides = append(overrides[:i:i], overrides[i+1:]...)
+			} else {
+				overrides[i].text = text
+			}
+			return nil
+		}
+	}
+
+	if text != "" {
+		overrides = append(overrides, override{head: errX, text: text})
+	}
+
+	return nil
+}
+
+// PublicMessage returns the user-facing message of the error: the override
+// of the first context in the chain or the message of the outermost fault.
+// Foreign errors are rendered as is.
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var msg string
+	walk(err, func(err error) bool {
+		e, ok := err.(*errType)
+		if !ok {
+			return true
+		}
+
+		if text, has := overrideOf(e.head); has {
+			msg = text
+			return false
+		}
+
+		if msg == "" {
+			msg = e.Message()
+		}
+		return true
+	})
+
+	if msg == "" {
+		return err.Error()
+	}
+
+	return msg
+}
+
+func overrideOf(head error) (string, bool) {
+	if !cfg.Load().overrides {
+		return "", false
+	}
+
+	muOverrides.RLock()
+	defer muOverrides.RUnlock()
+
+	for _, x := range overrides {
+		if x.head == head {
+			return x.text, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestOverride(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Fast("b")
+	)
+
+	x := errA.With(errB.With(err), 1)
+
+	if e := errors.Override(errB, "b is degraded"); !errors.ErrOverrideDisabled.Check(e) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if msg := errors.PublicMessage(x); msg != "a 1" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	errors.Configure(errors.Overrides(true))
+	defer errors.Configure(errors.Overrides(false))
+
+	if e := errors.Override(errB, "b is degraded"); e != nil {
+		t.Errorf("failed: %v", e)
+	}
+	defer errors.Override(errB, "")
+
+	if msg := errors.PublicMessage(x); msg != "b is degraded" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	if msg := x.Error(); msg != at("TestOverride", 23)+"a 1: b: just error" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	errors.Override(errB, "")
+	if msg := errors.PublicMessage(x); msg != "a 1" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	if msg := errors.PublicMessage(err); msg != "just error" {
+		t.Errorf("failed: %s", msg)
+	}
+
+	if msg := errors.PublicMessage(nil); msg != "" {
+		t.Errorf("failed: %s", msg)
+	}
+}
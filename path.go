@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+// hop is the error annotated with the service it has crossed, see Hop
+type hop struct {
+	cause
+	service string
+}
+
+func (e *hop) Error() string { return e.message("hop " + e.service) }
+func (e *hop) Hop() string   { return e.service }
+
+// unhop returns the error beneath hops
+func unhop(err error) error {
+	for {
+		h, ok := err.(*hop)
+		if !ok {
+			return err
+		}
+		err = h.err
+	}
+}
+
+// hopped is the fault beneath hops, it unwraps to hops so that the path
+// of the fault is preserved, see Encode.
+type hopped struct {
+	Fault
+	err error
+}
+
+func (e *hopped) Unwrap() error { return e.err }
+
+// Hop annotates the error with the service it crosses, transports append hops
+// when faults are received from other services (e.g. after Decode), so
+// the error tells the topology it has traveled. The message is not changed.
+//
+//	fault, err := faults.Decode("nats", msg.Data)
+//	if err == nil {
+//		return faults.Hop(fault, "ledger")
+//	}
+func Hop(err error, service string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &hop{cause: cause{err}, service: service}
+}
+
+// Path returns services traveled by the error, the latest hop first, e.g.
+// ["billing", "ledger", "db-proxy"]. Codecs encode the path of the fault
+// along with it (see Encode), transports restore it with Hop after Decode.
+func Path(err error) []string {
+	var seq []string
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ Hop() string }); ok {
+			seq = append(seq, e.Hop())
+		}
+		return true
+	})
+
+	return seq
+}
@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestPath(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+	)
+
+	origin := errB.With(err)
+	e := errors.Hop(errA.With(errors.Hop(errors.Hop(origin, "db-proxy"), "ledger")), "billing")
+
+	if path := errors.Path(e); fmt.Sprint(path) != "[billing ledger db-proxy]" {
+		t.Errorf("failed: %v", path)
+	}
+
+	if !stderrors.Is(e, err) || !stderrors.Is(e, errB) || errors.Hop(origin, "x").Error() != origin.Error() {
+		t.Errorf("failed: %v", e)
+	}
+
+	if kv := errors.LogFields(e); fmt.Sprint(kv["fault.path"]) != "[billing ledger db-proxy]" || kv["fault.cause.0.type"] != "*faults.errType" {
+		t.Errorf("failed: %v", kv)
+	}
+
+	if errors.Path(origin) != nil || errors.Hop(nil, "x") != nil {
+		t.Errorf("failed: path")
+	}
+}
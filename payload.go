@@ -0,0 +1,83 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "errors"
+
+// Of creates a context for the error that carries typed payload, e.g.
+// request ids or entity snapshots, without stringifying it. The payload
+// is not used to render the message, see Payload.
+//
+//	type Request struct { ID string }
+//
+//	const errSome = faults.Of[Request]("unable to serve request")
+type Of[T any] string
+
+// With wraps error into the context along with payload.
+//
+//	if err := doSomething(); err != nil {
+//		return nil, errSome.With(err, Request{ID: id})
+//	}
+func (e Of[T]) With(err error, payload T) error {
+	x := newFault(e, err, nil)
+	x.payload = payload
+	return withCaller(0, x)
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (e Of[T]) Maybe(err error, payload T) error {
+	if err == nil {
+		return nil
+	}
+
+	x := newFault(e, err, nil)
+	x.payload = payload
+	return withCaller(0, x)
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (e Of[T]) WrapOnce(err error, payload T) error {
+	if carries(err, e) {
+		return err
+	}
+
+	x := newFault(e, err, nil)
+	x.payload = payload
+	return withCaller(0, x)
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (e Of[T]) Check(err error) bool { return errors.Is(err, e) }
+
+func (e Of[T]) Error() string { return string(e) }
+func (e Of[T]) arity() int    { return 0 }
+func (e Of[T]) zero() string  { return string(e) }
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (e Of[T]) MatchAlso(errs ...error) Of[T] {
+	matchAlso(e, errs)
+	return e
+}
+
+// Payload returns the payload of the first fault in the chain that
+// carries the payload of the type.
+//
+//	if req, ok := faults.Payload[Request](err); ok {
+//		...
+//	}
+func Payload[T any](err error) (payload T, ok bool) {
+	walk(err, func(err error) bool {
+		if e, is := err.(*errType); is {
+			payload, ok = e.payload.(T)
+		}
+		return !ok
+	})
+
+	return
+}
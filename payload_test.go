@@ -0,0 +1,60 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+type request struct{ ID string }
+
+type entity struct{ Key, Val string }
+
+func TestPayload(t *testing.T) {
+	const (
+		errA = errors.Of[request]("unable to serve request")
+		errB = errors.Of[*entity]("unable to write entity")
+		errC = errors.Type("c")
+	)
+
+	e := errC.With(errA.With(errB.With(err, &entity{"k", "v"}), request{"r1"}))
+
+	if e.Error() != at("TestPayload", 29)+"c: "+at("TestPayload", 29)+"unable to serve request: "+at("TestPayload", 29)+"unable to write entity: just error" {
+		t.Errorf("failed: %s", e)
+	}
+
+	if !errA.Check(e) || !errB.Check(e) || !stderrors.Is(e, err) {
+		t.Errorf("failed: %v", e)
+	}
+
+	if req, ok := errors.Payload[request](e); !ok || req.ID != "r1" {
+		t.Errorf("failed: %v", req)
+	}
+
+	if ent, ok := errors.Payload[*entity](e); !ok || ent.Key != "k" {
+		t.Errorf("failed: %v", ent)
+	}
+
+	if _, ok := errors.Payload[string](e); ok {
+		t.Errorf("failed: string payload")
+	}
+
+	if _, ok := errors.Payload[request](err); ok {
+		t.Errorf("failed: foreign error")
+	}
+
+	if errA.Maybe(nil, request{}) != nil {
+		t.Errorf("failed: maybe")
+	}
+
+	errors.Catalog{errA, errB}.SelfTest(t)
+}
@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+// Pipe annotates every error passing through the channel with the context.
+// The caller of Pipe is used as the origin of errors, which is useful for
+// event-loop architectures where errors travel via channels and lose the
+// context. Nil errors are dropped. The output channel is closed when
+// the input channel is closed.
+//
+//	const errWorker = faults.Type("worker is failed")
+//
+//	for err := range faults.Pipe(worker.Errors(), errWorker) {
+//		log.Println(err)
+//	}
+func Pipe(errs <-chan error, errX Type, args ...any) <-chan error {
+	var pc uintptr
+	if captureCallers && !cfg.Load().skipCallers {
+		pc = callerPC(0)
+	}
+
+	out := make(chan error, cap(errs))
+
+	go func() {
+		defer close(out)
+
+		for err := range errs {
+			if err == nil {
+				continue
+			}
+
+			fault := newErrType(errX, err, args)
+			fault.pc = pc
+			out <- seal(fault)
+		}
+	}()
+
+	return out
+}
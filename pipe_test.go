@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestPipe(t *testing.T) {
+	const errA = errors.Type("a %d")
+
+	in := make(chan error)
+	go func() {
+		in <- err
+		in <- nil
+		in <- err
+		close(in)
+	}()
+
+	n := 0
+	for e := range errors.Pipe(in, errA, 1) {
+		n++
+		if !stderrors.Is(e, errA) || !stderrors.Is(e, err) {
+			t.Errorf("failed: %v", e)
+		}
+
+		if e.Error() != at("TestPipe", 30)+"a 1: just error" {
+			t.Errorf("failed: %v", e)
+		}
+	}
+
+	if n != 2 {
+		t.Errorf("failed: %d errors", n)
+	}
+}
+
+func TestPipeNoCallers(t *testing.T) {
+	const errA = errors.Type("a")
+
+	errors.Configure(errors.Callers(false))
+	defer errors.Configure(errors.Callers(true))
+
+	in := make(chan error, 1)
+	in <- err
+	close(in)
+
+	for e := range errors.Pipe(in, errA) {
+		if name, _ := e.(errors.Fault).Caller(); name != "" || e.Error() != "a: just error" {
+			t.Errorf("failed: caller is captured %v", e)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+var pool = sync.Pool{New: func() any { return new(errType) }}
+
+// Pooling enables reuse of faults released by Release, so that services
+// wrapping millions of errors do not pressure the GC. Pooling is disabled
+// by default.
+func Pooling(enabled bool) Option {
+	return func(c *config) { c.pooling = enabled }
+}
+
+// acquire allocates the fault, reusing released one if pooling is enabled
+func acquire() *errType {
+	if cfg.Load().pooling {
+		return pool.Get().(*errType)
+	}
+
+	return new(errType)
+}
+
+// Release returns faults of the chain to the pool once the error is handled
+// (e.g. logged). Neither the error nor any of its causes produced by
+// contexts must be used after the release. It is no-op unless pooling is
+// enabled.
+//
+//	faults.Configure(faults.Pooling(true))
+//
+//	if err := doSomething(); err != nil {
+//		slog.Error("failed", "err", err)
+//		faults.Release(err)
+//	}
+func Release(err error) {
+	if !cfg.Load().pooling {
+		return
+	}
+
+	var seq []*errType
+	walk(err, func(err error) bool {
+		if e, ok := err.(*errType); ok && !slices.Contains(seq, e) {
+			seq = append(seq, e)
+		}
+		return true
+	})
+
+	for _, e := range seq {
+		e.reset()
+		pool.Put(e)
+	}
+}
+
+// reset zeroes the fault, caches are cleared explicitly
+func (e *errType) reset() {
+	e.pc = 0
+	e.loc.Store(nil)
+	e.args = nil
+	e.fields = nil
+	e.head = nil
+	e.tail = nil
+	e.stack = nil
+	e.payload = nil
+	e.at = time.Time{}
+	e.build = nil
+	e.text.Store(nil)
+	e.msg.Store(nil)
+	e.inspection.Store(nil)
+	e.occurrence.Store(0)
+	e.guard = guard{}
+}
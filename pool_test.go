@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestPooling(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Safe1[string]("b %s")
+	)
+
+	errors.Configure(errors.Pooling(true))
+	defer errors.Configure(errors.Pooling(false))
+
+	for i := 0; i < 100; i++ {
+		e := errA.With(errB.With(err, "x"), i)
+		if !errA.Check(e) || !errB.Check(e) || !stderrors.Is(e, err) {
+			t.Errorf("failed: %v", e)
+		}
+
+		var fault errors.Fault
+		if !stderrors.As(e, &fault) || fault.Message() != errA.With(nil, i).(errors.Fault).Message() {
+			t.Errorf("failed: %v", e)
+		}
+
+		if errors.ClassOf(e) != "" {
+			t.Errorf("failed: %v", e)
+		}
+
+		errors.Release(e)
+	}
+
+	e := errB.With(errors.ErrGone(err), "gone")
+	if errors.ClassOf(e) != errors.ClassGone || e.Error() != at("TestPooling", 45)+"b gone: just error" {
+		t.Errorf("failed: %v", e)
+	}
+	errors.Release(e)
+}
+
+func TestReleaseNoPooling(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(err)
+	errors.Release(e)
+
+	if !errA.Check(e) || !stderrors.Is(e, err) {
+		t.Errorf("failed: %v", e)
+	}
+}
+
+func BenchmarkPooling(b *testing.B) {
+	const errA = errors.FastSafe1[int]("a %d")
+
+	errors.Configure(errors.Pooling(true))
+	defer errors.Configure(errors.Pooling(false))
+
+	var e error
+
+	for n := 0; n < b.N; n++ {
+		e = errA.With(err, n)
+		errors.Release(e)
+	}
+
+	glo = e
+}
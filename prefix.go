@@ -0,0 +1,44 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+// Prefix namespaces messages of contexts declared by the package, so that
+// all errors of the package start with its name without repeating it in
+// every template.
+//
+//	var pkg = faults.Prefixed("mypkg: ")
+//
+//	var (
+//		errSomeA = pkg.Type("something is failed")
+//		errSomeB = faults.Declare[faults.Safe1[int]](pkg, "something %d is failed")
+//	)
+type Prefix string
+
+// Prefixed creates the factory of contexts namespaced by the prefix
+func Prefixed(prefix string) Prefix { return Prefix(prefix) }
+
+// Type declares prefixed context, see Type
+func (p Prefix) Type(template string) Type { return Type(string(p) + template) }
+
+// Fast declares prefixed context, see Fast
+func (p Prefix) Fast(template string) Fast { return Fast(string(p) + template) }
+
+// Deep declares prefixed context, see Deep
+func (p Prefix) Deep(template string) Deep { return Deep(string(p) + template) }
+
+// Named declares prefixed context, see Named
+func (p Prefix) Named(template string) Named { return Named(string(p) + template) }
+
+// Code declares prefixed context, see Code
+func (p Prefix) Code(code, text string) Coded { return Code(code, string(p)+text) }
+
+// Declare declares prefixed context of any string-based kind, e.g. SafeN.
+//
+//	var errSome = faults.Declare[faults.Safe2[int, string]](pkg, "something %d is failed %s")
+func Declare[T ~string](p Prefix, template string) T { return T(string(p) + template) }
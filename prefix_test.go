@@ -0,0 +1,49 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestPrefixed(t *testing.T) {
+	pkg := errors.Prefixed("pkg: ")
+
+	var (
+		errA = pkg.Type("a")
+		errB = pkg.Fast("b")
+		errC = pkg.Deep("c")
+		errD = pkg.Named("d {x}")
+		errE = pkg.Code("E", "e")
+		errF = errors.Declare[errors.Safe2[int, string]](pkg, "f %d %s")
+	)
+
+	for expect, e := range map[string]error{
+		"pkg: a":       errA.With(nil),
+		"pkg: b":       errB.With(nil),
+		"pkg: c":       errC.With(nil),
+		"pkg: d 1":     errD.With(nil, "x", 1),
+		"pkg: e":       errE.With(nil),
+		"pkg: f 1 one": errF.With(nil, 1, "one"),
+	} {
+		var fault errors.Fault
+		if !errors.OneOf(e, errA, errB, errC, errD, errE, errF) || !stderrors.As(e, &fault) || fault.Message() != expect {
+			t.Errorf("failed: %v", e)
+		}
+	}
+
+	if errE.ErrCode() != "E" {
+		t.Errorf("failed: %v", errE)
+	}
+
+	errors.Catalog{errA, errB, errC, errD, errE, errF}.SelfTest(t)
+}
@@ -10,24 +10,57 @@ package faults
 
 import (
 	"errors"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Timeout interface{ Timeout() time.Duration }
 
 func IsTimeout(err error, deadline time.Duration) bool {
-	var e interface{ Timeout() time.Duration }
-
-	ok := errors.As(err, &e)
+	e, ok := Extract[Timeout](err)
 	return ok && e.Timeout() >= deadline
 }
 
+// TotalTimeout sums timeouts of all errors in the chain, e.g. the time burned
+// by retry loops wrapping timeouts of previous attempts.
+//
+//	var err error
+//	for attempt := 0; attempt < 3; attempt++ {
+//		if cause := db.Get(ctx, key); cause != nil {
+//			err = errRetry.WithAll(faults.ErrTimeout(cause, timeout), err)
+//		}
+//	}
+//
+//	slog.Warn("retries are exhausted", "burned", faults.TotalTimeout(err))
+func TotalTimeout(err error) time.Duration {
+	var total time.Duration
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ Timeout() time.Duration }); ok {
+			total += e.Timeout()
+		}
+		return true
+	})
+
+	return total
+}
+
+// NearTimeout operation has completed close to its deadline. Adaptive
+// systems shed load pre-emptively before hard timeouts occur.
+type NearTimeout interface{ NearTimeout() time.Duration }
+
+func IsNearTimeout(err error) bool {
+	e, ok := Extract[NearTimeout](err)
+	return ok && e.NearTimeout() > 0
+}
+
 type NotFound interface{ NotFound() string }
 
 func IsNotFound(err error, key ...string) bool {
-	var e interface{ NotFound() string }
-
-	if ok := errors.As(err, &e); !ok {
+	e, ok := Extract[NotFound](err)
+	if !ok {
 		return false
 	}
 
@@ -44,12 +77,40 @@ func IsNotFound(err error, key ...string) bool {
 	return false
 }
 
+// NotFoundOf is NotFound behavior of the typed key (e.g. UUID, composite
+// struct), see IsNotFoundKey.
+type NotFoundOf[K comparable] interface{ NotFoundKey() K }
+
+// IsNotFoundKey returns true if the error is NotFound of one of typed keys,
+// keys are compared without stringification.
+//
+//	if faults.IsNotFoundKey(err, id) {
+//		...
+//	}
+func IsNotFoundKey[K comparable](err error, key ...K) bool {
+	e, ok := Extract[NotFoundOf[K]](err)
+	if !ok {
+		return false
+	}
+
+	if len(key) == 0 {
+		return true
+	}
+
+	for _, x := range key {
+		if e.NotFoundKey() == x {
+			return true
+		}
+	}
+
+	return false
+}
+
 type StatusCode interface{ StatusCode() string }
 
 func IsStatusCode(err error, code ...string) bool {
-	var e interface{ StatusCode() string }
-
-	if ok := errors.As(err, &e); !ok {
+	e, ok := Extract[StatusCode](err)
+	if !ok {
 		return false
 	}
 
@@ -66,33 +127,194 @@ func IsStatusCode(err error, code ...string) bool {
 	return false
 }
 
+// HTTPStatus is the numeric status code (e.g. HTTP, gRPC), the string-based
+// StatusCode is bridged by IsStatus and StatusOf.
+type HTTPStatus interface{ HTTPStatus() int }
+
+// IsStatus returns true if the status of the error is one of codes, any status
+// matches if codes are not given, see StatusOf.
+//
+//	if faults.IsStatus(err, http.StatusTooManyRequests, http.StatusServiceUnavailable) {
+//		...
+//	}
+func IsStatus(err error, code ...int) bool {
+	status, ok := StatusOf(err)
+	if !ok {
+		return false
+	}
+
+	if len(code) == 0 {
+		return true
+	}
+
+	for _, x := range code {
+		if status == x {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusOf returns the numeric status of the first error in the chain that
+// exposes either `HTTPStatus() int` or numeric `StatusCode() string`,
+// non-numeric codes are skipped.
+func StatusOf(err error) (int, bool) {
+	var status int
+
+	walk(err, func(err error) bool {
+		switch e := err.(type) {
+		case interface{ HTTPStatus() int }:
+			status = e.HTTPStatus()
+		case interface{ StatusCode() string }:
+			status, _ = strconv.Atoi(e.StatusCode())
+		}
+		return status == 0
+	})
+
+	return status, status != 0
+}
+
 type PreConditionFailed interface{ PreConditionFailed() bool }
 
 func IsPreConditionFailed(err error) bool {
-	var e interface{ PreConditionFailed() bool }
-
-	ok := errors.As(err, &e)
+	e, ok := Extract[PreConditionFailed](err)
 	return ok && e.PreConditionFailed()
 }
 
 type Conflict interface{ Conflict() bool }
 
 func IsConflict(err error) bool {
-	var e interface{ Conflict() bool }
-
-	ok := errors.As(err, &e)
+	e, ok := Extract[Conflict](err)
 	return ok && e.Conflict()
 }
 
+// ConflictKey is the key of the conflict, see ConflictOf.
+type ConflictKey interface{ ConflictKey() string }
+
+// ConflictOf returns the key of the first conflict in the chain that reports
+// it via `ConflictKey() string`.
+//
+//	if key, ok := faults.ConflictOf(err); ok {
+//		...
+//	}
+func ConflictOf(err error) (string, bool) {
+	var key string
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ ConflictKey() string }); ok && e.ConflictKey() != "" {
+			key = e.ConflictKey()
+			return false
+		}
+		return true
+	})
+
+	return key, key != ""
+}
+
 type Gone interface{ Gone() bool }
 
 func IsGone(err error) bool {
-	var e interface{ Gone() bool }
-
-	ok := errors.As(err, &e)
+	e, ok := Extract[Gone](err)
 	return ok && e.Gone()
 }
 
+// Degraded dependency serves requests with impaired quality (e.g. increased
+// latency, partial results). The caller should shed load.
+type Degraded interface{ Degraded() bool }
+
+func IsDegraded(err error) bool {
+	e, ok := Extract[Degraded](err)
+	return ok && e.Degraded()
+}
+
+// Down dependency does not serve requests at all. The caller should fail fast.
+type Down interface{ Down() bool }
+
+func IsDown(err error) bool {
+	e, ok := Extract[Down](err)
+	return ok && e.Down()
+}
+
+// Retryable operation might succeed if it is retried, retry middleware
+// decides on it without matching messages. Errors without the behavior are
+// retryable according to the mapping of their class, see MappingOf.
+type Retryable interface{ Retryable() bool }
+
+func IsRetryable(err error) bool {
+	if e, ok := Extract[Retryable](err); ok {
+		return e.Retryable()
+	}
+
+	class := ClassOf(err)
+	return class != "" && mappingOf(class).Retryable
+}
+
+// Validation of the input is failed, violations are rendered field by field,
+// see ViolationsOf.
+type Validation interface{ Violations() []Violation }
+
+func IsValidation(err error) bool {
+	_, ok := ViolationsOf(err)
+	return ok
+}
+
+// ViolationsOf returns violations of the first error in the chain that
+// exposes them via `Violations() []Violation`.
+//
+//	if seq, ok := faults.ViolationsOf(err); ok {
+//		json.NewEncoder(w).Encode(seq)
+//	}
+func ViolationsOf(err error) ([]Violation, bool) {
+	e, ok := Extract[Validation](err)
+	if !ok || len(e.Violations()) == 0 {
+		return nil, false
+	}
+
+	return e.Violations(), true
+}
+
+// IsTransient returns true if the first error in the chain annotated via
+// `Transient() bool` is transient, see Transient and Permanent. The outer
+// annotation overrides inner ones.
+//
+//	if faults.IsTransient(err) {
+//		queue.Requeue(msg)
+//	}
+func IsTransient(err error) bool {
+	e, ok := Extract[interface{ Transient() bool }](err)
+	return ok && e.Transient()
+}
+
+// IsPermanent returns true if the first error in the chain annotated via
+// `Transient() bool` is permanent, see IsTransient.
+func IsPermanent(err error) bool {
+	e, ok := Extract[interface{ Transient() bool }](err)
+	return ok && !e.Transient()
+}
+
+// Expected fault is the normal outcome of the call (e.g. NotFound on
+// GET-by-id), it is logged at debug level and excluded from the error rate.
+type Expected interface{ Expected() string }
+
+func IsExpected(err error) bool {
+	_, ok := Extract[Expected](err)
+	return ok
+}
+
+// Severity of the failure, see SeverityOf.
+type Severity interface{ Severity() SeverityLevel }
+
+// Temporary failure is expected to be resolved, it is aligned with legacy
+// `Temporary() bool` convention of net.Error, network failures are
+// recognized along with domain faults.
+type Temporary interface{ Temporary() bool }
+
+func IsTemporary(err error) bool {
+	e, ok := Extract[Temporary](err)
+	return ok && e.Temporary()
+}
+
 type Issue interface {
 	ErrCode() string
 	ErrType() string
@@ -100,3 +322,151 @@ type Issue interface {
 	ErrTitle() string
 	ErrDetail() string
 }
+
+// RateLimited dependency throttles requests, the caller should back off,
+// see RetryAfter for the advertised backoff.
+type RateLimited interface{ RateLimited() bool }
+
+func IsRateLimited(err error) bool {
+	e, ok := Extract[RateLimited](err)
+	return ok && e.RateLimited()
+}
+
+// RetryAfter returns the backoff hint from the first error in the chain that
+// exposes a positive retry-after value via `RetryAfter() time.Duration`.
+//
+//	if d, ok := faults.RetryAfter(err); ok {
+//		time.Sleep(d)
+//	}
+func RetryAfter(err error) (time.Duration, bool) {
+	var after time.Duration
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ RetryAfter() time.Duration }); ok && e.RetryAfter() > 0 {
+			after = e.RetryAfter()
+			return false
+		}
+		return true
+	})
+
+	return after, after > 0
+}
+
+// ParseRetryAfter parses the value of HTTP Retry-After header, either
+// delta-seconds or HTTP-date relative to the clock (see Clock). Dates in
+// the past are zero backoff, invalid values are rejected.
+//
+//	if d, ok := faults.ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+//		time.Sleep(d)
+//	}
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if sec < 0 {
+			return 0, false
+		}
+		return time.Duration(sec) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	d := at.Sub(cfg.Load().clock())
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+// walk traverses the error tree in pre-order until f returns false.
+func walk(err error, f func(error) bool) bool {
+	for err != nil {
+		if !f(err) {
+			return false
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, err := range x.Unwrap() {
+				if !walk(err, f) {
+					return false
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// Walk traverses the unwrap tree of the error in pre-order, including
+// branches of multi-errors, until f returns false. The depth of the error
+// is the number of unwraps from the root, causes and contexts of the fault
+// are one level deeper than the fault.
+//
+//	faults.Walk(err, func(e error, depth int) bool {
+//		fmt.Printf("%s%T\n", strings.Repeat("  ", depth), e)
+//		return true
+//	})
+func Walk(err error, f func(err error, depth int) bool) {
+	walkDepth(err, 0, f)
+}
+
+func walkDepth(err error, depth int, f func(error, int) bool) bool {
+	for err != nil {
+		if !f(err, depth) {
+			return false
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+			depth++
+		case interface{ Unwrap() []error }:
+			for _, err := range x.Unwrap() {
+				if !walkDepth(err, depth+1, f) {
+					return false
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// Extract returns the first error in the chain that matches the type T,
+// usually the behavior interface, it is sugar over errors.As. The T is
+// either interface or the type implementing error as errors.As requires.
+//
+//	if e, ok := faults.Extract[faults.NotFound](err); ok {
+//		e.NotFound()
+//	}
+func Extract[T any](err error) (T, bool) {
+	var e T
+
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// Has returns true if any error in the chain matches the type T, see Extract.
+//
+//	if faults.Has[interface{ Throttled() bool }](err) {
+//		...
+//	}
+func Has[T any](err error) bool {
+	_, ok := Extract[T](err)
+	return ok
+}
@@ -0,0 +1,188 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+type retryAfter time.Duration
+
+func (e retryAfter) Error() string             { return "retry after" }
+func (e retryAfter) RetryAfter() time.Duration { return time.Duration(e) }
+
+func TestRetryAfter(t *testing.T) {
+	const errA = errors.Type("a")
+
+	if d, ok := errors.RetryAfter(errA.With(retryAfter(time.Second))); !ok || d != time.Second {
+		t.Errorf("failed: retry after %v", d)
+	}
+
+	nested := fmt.Errorf("b: %w", retryAfter(0))
+	joined := stderrors.Join(err, errA.With(nested), retryAfter(2*time.Second))
+	if d, ok := errors.RetryAfter(joined); !ok || d != 2*time.Second {
+		t.Errorf("failed: retry after %v", d)
+	}
+
+	if _, ok := errors.RetryAfter(errA.With(err)); ok {
+		t.Errorf("failed: retry after is found")
+	}
+
+	if _, ok := errors.RetryAfter(nil); ok {
+		t.Errorf("failed: retry after is found")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, time.October, 21, 7, 28, 0, 0, time.UTC)
+
+	errors.Configure(errors.Clock(func() time.Time { return now }))
+	defer errors.Configure(errors.Clock(nil))
+
+	for _, tt := range []struct {
+		value string
+		after time.Duration
+		ok    bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{"Wed, 21 Oct 2015 07:30:00 GMT", 2 * time.Minute, true},
+		{"Wed, 21 Oct 2015 07:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"1.5", 0, false},
+		{"tomorrow", 0, false},
+	} {
+		if after, ok := errors.ParseRetryAfter(tt.value); after != tt.after || ok != tt.ok {
+			t.Errorf("failed: %q is %v %v", tt.value, after, ok)
+		}
+	}
+
+	e := errors.ErrRetryAfter(err, "Wed, 21 Oct 2015 07:30:00 GMT")
+	if d, ok := errors.RetryAfter(errors.Type("a").With(e)); !ok || d != 2*time.Minute || !stderrors.Is(e, err) {
+		t.Errorf("failed: retry after %v", d)
+	}
+
+	if e := errors.ErrRetryAfter(err, "tomorrow"); e != err || errors.ErrRetryAfter(nil, "120") != nil {
+		t.Errorf("failed: invalid retry after %v", e)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrRateLimited(err, time.Second))
+	if !errors.IsRateLimited(e) || errors.IsRateLimited(errA.With(err)) {
+		t.Errorf("failed: rate limited %v", e)
+	}
+
+	if d, ok := errors.RetryAfter(e); !ok || d != time.Second {
+		t.Errorf("failed: retry after %v", d)
+	}
+
+	if !errors.IsRateLimited(errors.ErrRateLimited(err, 0)) || errors.ErrRateLimited(nil, time.Second) != nil {
+		t.Errorf("failed: rate limited without hint")
+	}
+
+	if _, ok := errors.RetryAfter(errors.ErrRateLimited(err, 0)); ok {
+		t.Errorf("failed: retry after without hint")
+	}
+
+	if errors.IsRateLimited(errA.With(retryAfter(time.Second))) {
+		t.Errorf("failed: retry after hint is not rate limited")
+	}
+
+	if !stderrors.Is(e, err) || errors.Inspect(e).RetryAfter != time.Second {
+		t.Errorf("failed: rate limited %v", e)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Fast("b")
+	)
+
+	e := errA.WithAll(fmt.Errorf("x: %w", err), errB.With(err))
+
+	var seq []string
+	errors.Walk(e, func(e error, depth int) bool {
+		seq = append(seq, fmt.Sprintf("%d %s", depth, e.Error()))
+		return true
+	})
+
+	expect := []string{"1 a", "1 x: just error", "2 just error", "1 b: just error", "2 b", "2 just error"}
+	if len(seq) != 7 || strings.Join(seq[1:], "|") != strings.Join(expect, "|") {
+		t.Errorf("failed: %q", seq)
+	}
+
+	n := 0
+	errors.Walk(e, func(e error, depth int) bool {
+		n++
+		return depth < 1
+	})
+	if n != 2 {
+		t.Errorf("failed: walk is not stopped")
+	}
+
+	errors.Walk(nil, func(e error, depth int) bool {
+		t.Errorf("failed: nil")
+		return true
+	})
+}
+
+func TestTotalTimeout(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errors.ErrTimeout(errA.With(errors.ErrTimeout(errA.With(err), time.Second)), 2*time.Second)
+	if d := errors.TotalTimeout(e); d != 3*time.Second {
+		t.Errorf("failed: %v", d)
+	}
+
+	e = errA.WithAll(errors.ErrTimeout(err, time.Second), errors.ErrTimeout(err, time.Second), err)
+	if d := errors.TotalTimeout(e); d != 2*time.Second {
+		t.Errorf("failed: %v", d)
+	}
+
+	if d := errors.TotalTimeout(errA.With(err)); d != 0 {
+		t.Errorf("failed: %v", d)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(errors.ErrNotFound(err, "k"))
+
+	if x, ok := errors.Extract[errors.NotFound](e); !ok || x.NotFound() != "k" {
+		t.Errorf("failed: extract %v", x)
+	}
+
+	if x, ok := errors.Extract[errors.NotFoundError](e); !ok || x.Key() != "k" {
+		t.Errorf("failed: extract %v", x)
+	}
+
+	if _, ok := errors.Extract[errors.Conflict](e); ok {
+		t.Errorf("failed: extract conflict")
+	}
+
+	if !errors.Has[interface{ RetryAfter() time.Duration }](errA.With(retryAfter(time.Second))) {
+		t.Errorf("failed: has retry after")
+	}
+
+	if errors.Has[errors.Down](e) || errors.Has[errors.NotFound](nil) {
+		t.Errorf("failed: has")
+	}
+}
@@ -0,0 +1,79 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync/atomic"
+)
+
+// FaultRef is a compact, comparable reference to the fault for application
+// logs, while the full chain goes to the error sink only once.
+type FaultRef struct {
+	// Code of the first fault in the chain that declares it
+	Code string
+
+	// Fingerprint of the error origin, see Fingerprint
+	Fingerprint string
+
+	// Occurrence identity of the fault, it is unique within the process
+	Occurrence uint64
+}
+
+// String renders the reference as "code#fingerprint-occurrence"
+// (e.g. E-STOR-01#ab12cd-1f). The occurrence is omitted for errors
+// without faults.
+func (ref FaultRef) String() string {
+	if ref.Occurrence == 0 {
+		return ref.Code + "#" + ref.Fingerprint
+	}
+
+	return ref.Code + "#" + ref.Fingerprint + "-" + strconv.FormatUint(ref.Occurrence, 16)
+}
+
+var occurrences atomic.Uint64
+
+// Ref returns the compact reference to the error. The occurrence identity is
+// assigned to the first fault in the chain once, consequent calls return
+// the same reference.
+//
+//	slog.Error("request is failed", "err", faults.Ref(err))
+func Ref(err error) FaultRef {
+	if err == nil {
+		return FaultRef{}
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(Fingerprint(err)))
+
+	ref := FaultRef{Fingerprint: fmt.Sprintf("%06x", h.Sum32()&0xffffff)}
+
+	walk(err, func(err error) bool {
+		e, ok := err.(*errType)
+		if !ok {
+			return true
+		}
+
+		if ref.Occurrence == 0 {
+			e.occurrence.CompareAndSwap(0, occurrences.Add(1))
+			ref.Occurrence = e.occurrence.Load()
+		}
+
+		if code := e.Code(); code != "" {
+			ref.Code = code
+			return false
+		}
+
+		return true
+	})
+
+	return ref
+}
@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestRef(t *testing.T) {
+	const errA = errors.Type("a")
+
+	e := errA.With(err)
+
+	a, b := errors.Ref(e), errors.Ref(fmt.Errorf("b: %w", e))
+	if a != b || a.Occurrence == 0 || len(a.Fingerprint) == 0 {
+		t.Errorf("failed: %v != %v", a, b)
+	}
+
+	if !strings.HasPrefix(a.String(), "#"+a.Fingerprint+"-") {
+		t.Errorf("failed: %s", a)
+	}
+
+	c := errors.Ref(errA.With(err))
+	if c == a || (withCallers && c.Fingerprint == a.Fingerprint) {
+		t.Errorf("failed: %v == %v", a, c)
+	}
+
+	d := errors.Ref(err)
+	if d.Occurrence != 0 || d.Fingerprint == "" {
+		t.Errorf("failed: %v", d)
+	}
+
+	if errors.Ref(nil) != (errors.FaultRef{}) {
+		t.Errorf("failed: nil")
+	}
+}
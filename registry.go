@@ -0,0 +1,146 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// registry of the binary is copy-on-write, writers are serialized by
+// the mutex, readers load the snapshot without locks.
+var (
+	muRegistry sync.Mutex
+	registry   atomic.Pointer[registryState]
+)
+
+type registryState struct {
+	decls     Catalog
+	templates map[string]struct{}
+	codes     map[string]struct{}
+}
+
+func (s *registryState) clone() *registryState {
+	c := &registryState{
+		templates: map[string]struct{}{},
+		codes:     map[string]struct{}{},
+	}
+
+	if s != nil {
+		c.decls = append(Catalog(nil), s.decls...)
+		for k := range s.templates {
+			c.templates[k] = struct{}{}
+		}
+		for k := range s.codes {
+			c.codes[k] = struct{}{}
+		}
+	}
+
+	return c
+}
+
+func codeOf(decl error) string {
+	if c, ok := decl.(interface{ ErrCode() string }); ok {
+		return c.ErrCode()
+	}
+	return ""
+}
+
+// Register declares contexts of the package in the registry of the binary.
+// It panics if the value is not a context or the template or the code is
+// registered twice, similarly to sql.Register. The registry is not changed
+// if it panics.
+//
+//	var (
+//		errSomeA = faults.Type("something is failed")
+//		errSomeB = faults.Code("E1042", "unable to do something")
+//	)
+//
+//	func init() {
+//		faults.Register(errSomeA, errSomeB)
+//	}
+func Register(decls ...error) {
+	muRegistry.Lock()
+	defer muRegistry.Unlock()
+
+	state := registry.Load().clone()
+
+	for _, decl := range decls {
+		if _, ok := decl.(declaration); !ok {
+			panic(fmt.Sprintf("faults: %q is not a fault declaration", decl))
+		}
+
+		template := decl.Error()
+		if _, has := state.templates[template]; has {
+			panic(fmt.Sprintf("faults: %q is registered twice", template))
+		}
+
+		if code := codeOf(decl); code != "" {
+			if _, has := state.codes[code]; has {
+				panic(fmt.Sprintf("faults: code %s of %q is registered twice", code, template))
+			}
+			state.codes[code] = struct{}{}
+		}
+
+		state.templates[template] = struct{}{}
+		state.decls = append(state.decls, decl)
+	}
+
+	registry.Store(state)
+}
+
+// Deregister removes contexts from the registry of the binary, e.g. when
+// the plugin is unloaded. Unknown contexts are ignored.
+//
+//	func (p *plugin) Close() error {
+//		faults.Deregister(errSomeA, errSomeB)
+//		return nil
+//	}
+func Deregister(decls ...error) {
+	muRegistry.Lock()
+	defer muRegistry.Unlock()
+
+	state := registry.Load().clone()
+
+	for _, decl := range decls {
+		template := decl.Error()
+		if _, has := state.templates[template]; !has {
+			continue
+		}
+
+		delete(state.templates, template)
+
+		for i, x := range state.decls {
+			if x.Error() == template {
+				delete(state.codes, codeOf(x))
+				state.decls = append(state.decls[:i], state.decls[i+1:]...)
+				break
+			}
+		}
+	}
+
+	registry.Store(state)
+}
+
+// Registered returns all contexts declared by the binary in order of
+// registration, use it for cataloging and testing. It does not block
+// concurrent registrations.
+//
+//	func TestFaults(t *testing.T) {
+//		faults.Registered().SelfTest(t)
+//	}
+func Registered() Catalog {
+	state := registry.Load()
+	if state == nil {
+		return nil
+	}
+
+	return append(Catalog(nil), state.decls...)
+}
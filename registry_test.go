@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestRegister(t *testing.T) {
+	var (
+		errA = errors.Type("registry a")
+		errB = errors.Code("R1", "registry b")
+		errC = errors.Safe1[int]("registry c %d")
+	)
+
+	errors.Register(errA, errB, errC)
+
+	seq := errors.Registered()
+	if len(seq) < 3 || seq[len(seq)-3] != errA || seq[len(seq)-2] != errB || seq[len(seq)-1] != errC {
+		t.Errorf("failed: %v", seq)
+	}
+	seq.SelfTest(t)
+
+	for _, decl := range []error{
+		errors.Fast("registry a"),
+		errors.Code("R1", "registry d"),
+		err,
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("failed: %v is registered", decl)
+				}
+			}()
+			errors.Register(decl)
+		}()
+	}
+
+	if n := len(errors.Registered()); n != len(seq) {
+		t.Errorf("failed: %d", n)
+	}
+}
+
+func TestDeregister(t *testing.T) {
+	var (
+		errA = errors.Type("deregister a")
+		errB = errors.Code("D1", "deregister b")
+	)
+
+	n := len(errors.Registered())
+
+	errors.Register(errA, errB)
+	errors.Deregister(errA, errB, errors.Type("deregister unknown"))
+
+	if seq := errors.Registered(); len(seq) != n {
+		t.Errorf("failed: %v", seq)
+	}
+
+	// both template and code are released
+	errors.Register(errors.Code("D1", "deregister a"))
+	errors.Deregister(errors.Code("D1", "deregister a"))
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			decl := errors.Type(fmt.Sprintf("concurrent %d", i))
+			for k := 0; k < 100; k++ {
+				errors.Register(decl)
+				errors.Registered()
+				errors.Deregister(decl)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,16 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+//go:build !faults_debug
+
+package faults
+
+type guard struct{}
+
+func seal(e *errType) *errType { return created(e) }
+func (e *errType) verify()     {}
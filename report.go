@@ -0,0 +1,74 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Summary of the error for support tickets. Support tooling shows the public
+// section to the client and attaches the internal section privately.
+type Summary struct {
+	// Public section: code, reference to the occurrence and hint
+	Public string
+
+	// Internal section: full chain along with callers
+	Internal string
+}
+
+func (s Summary) String() string {
+	return "--- public ---\n" + s.Public + "\n--- internal ---\n" + s.Internal
+}
+
+// Report summarizes the error for support tickets, see Summary. The hint
+// is the public message of the error, see PublicMessage. Errors without
+// faults are hinted by the HTTP status text of their class, their messages
+// never leak into the public section.
+//
+//	summary := faults.Report(err)
+//	ticket.Reply(summary.Public)
+//	ticket.Attach(summary.Internal)
+func Report(err error) Summary {
+	if err == nil {
+		return Summary{}
+	}
+
+	ref := Ref(err)
+
+	var public strings.Builder
+	if ref.Code != "" {
+		public.WriteString("code: " + ref.Code + "\n")
+	}
+	public.WriteString("reference: " + ref.String() + "\n")
+	public.WriteString("hint: " + hintOf(err) + "\n")
+
+	var internal strings.Builder
+	if class := ClassOf(err); class != "" {
+		internal.WriteString("class: " + string(class) + "\n")
+	}
+	fmt.Fprintf(&internal, "%+v\n", err)
+
+	return Summary{Public: public.String(), Internal: internal.String()}
+}
+
+// hintOf returns the public message of the error, the generic text of
+// the class is used if the chain has no faults.
+func hintOf(err error) string {
+	if Has[*errType](err) {
+		return PublicMessage(err)
+	}
+
+	if text := http.StatusText(MappingOf(err).HTTP); text != "" {
+		return text
+	}
+
+	return http.StatusText(http.StatusInternalServerError)
+}
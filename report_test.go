@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestReport(t *testing.T) {
+	var errA = errors.Code("E-RPT-1", "unable to do %s")
+
+	e := errA.With(errors.ErrGone(err), "a")
+	ref := errors.Ref(e)
+	s := errors.Report(e)
+
+	if s.Public != "code: E-RPT-1\nreference: "+ref.String()+"\nhint: unable to do a\n" {
+		t.Errorf("failed: %s", s.Public)
+	}
+
+	if withCallers && !strings.HasPrefix(s.Internal, "class: gone\nunable to do a\n\tgithub.com/fogfish/faults_test.TestReport:23\n") {
+		t.Errorf("failed: %s", s.Internal)
+	}
+
+	if str := s.String(); !strings.Contains(str, s.Public) || !strings.Contains(str, s.Internal) {
+		t.Errorf("failed: %s", str)
+	}
+
+	s = errors.Report(err)
+	if !strings.HasSuffix(s.Public, "hint: Internal Server Error\n") || s.Internal != "just error\n" {
+		t.Errorf("failed: %v", s)
+	}
+
+	if s := errors.Report(nil); s.Public != "" || s.Internal != "" {
+		t.Errorf("failed: %v", s)
+	}
+}
+
+func TestReportForeign(t *testing.T) {
+	secret := stderrors.New("dial tcp 10.0.0.3:5432: password=hunter2")
+
+	for _, e := range []error{
+		secret,
+		fmt.Errorf("db: %w", secret),
+		errors.ErrNotFound(secret, "k"),
+	} {
+		s := errors.Report(e)
+		if strings.Contains(s.Public, "hunter2") || strings.Contains(s.Public, "10.0.0.3") || strings.HasSuffix(strings.Split(s.Public, "\n")[0], "-0") {
+			t.Errorf("failed: %s", s.Public)
+		}
+
+		if !strings.Contains(s.Internal, "hunter2") {
+			t.Errorf("failed: %s", s.Internal)
+		}
+	}
+
+	if s := errors.Report(errors.ErrNotFound(secret, "k")); !strings.HasSuffix(s.Public, "hint: Not Found\n") {
+		t.Errorf("failed: %s", s.Public)
+	}
+}
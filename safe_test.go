@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestSafeIs(t *testing.T) {
+	const (
+		errS1 = errors.Safe1[int]("s")
+		errS2 = errors.Safe2[int, int]("s")
+		errS3 = errors.Safe3[int, int, int]("s")
+		errS4 = errors.Safe4[int, int, int, int]("s")
+		errS5 = errors.Safe5[int, int, int, int, int]("s")
+		errS6 = errors.Safe6[int, int, int, int, int, int]("s")
+		errS7 = errors.Safe7[int, int, int, int, int, int, int]("s")
+		errS8 = errors.Safe8[int, int, int, int, int, int, int, int]("s")
+		errS9 = errors.Safe9[int, int, int, int, int, int, int, int, int]("s")
+		errSA = errors.Safe10[int, int, int, int, int, int, int, int, int, int]("s")
+	)
+
+	heads := []error{errS1, errS2, errS3, errS4, errS5, errS6, errS7, errS8, errS9, errSA}
+	faults := []error{
+		errS1.With(err, 1),
+		errS2.With(err, 1, 2),
+		errS3.With(err, 1, 2, 3),
+		errS4.With(err, 1, 2, 3, 4),
+		errS5.With(err, 1, 2, 3, 4, 5),
+		errS6.With(err, 1, 2, 3, 4, 5, 6),
+		errS7.With(err, 1, 2, 3, 4, 5, 6, 7),
+		errS8.With(err, 1, 2, 3, 4, 5, 6, 7, 8),
+		errS9.With(err, 1, 2, 3, 4, 5, 6, 7, 8, 9),
+		errSA.With(err, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10),
+	}
+
+	for i, e := range faults {
+		for j, head := range heads {
+			if stderrors.Is(e, head) != (i == j) {
+				t.Errorf("failed: Safe%d matches Safe%d", i+1, j+1)
+			}
+		}
+
+		if !stderrors.Is(e, err) {
+			t.Errorf("failed: Safe%d does not match cause", i+1)
+		}
+	}
+
+	var (
+		errX = errors.Safe1[string]("s")
+		errY = errors.Safe1[int]("s")
+	)
+
+	if stderrors.Is(errX.With(err, "x"), errY) || !stderrors.Is(errY.With(err, 1), errS1) {
+		t.Errorf("failed: instantiations")
+	}
+
+	if stderrors.Is(errS1.With(err, 1), errors.Type("s")) {
+		t.Errorf("failed: Type")
+	}
+}
@@ -0,0 +1,97 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Sentinel assigns the class to the well-known error.
+type Sentinel struct {
+	Err   error
+	Class Class
+}
+
+// sentinels is the classification table of frequent stdlib sentinels, wrapped
+// stdlib errors classify sensibly out of the box, see ClassOf. Behaviors of
+// errors wrapping the sentinel take precedence. The table is copied on write,
+// errors are classified without locks.
+var (
+	muSentinels sync.Mutex
+	sentinels   atomic.Pointer[[]Sentinel]
+)
+
+func init() {
+	sentinels.Store(&[]Sentinel{
+		{context.DeadlineExceeded, ClassTimeout},
+		{os.ErrDeadlineExceeded, ClassTimeout},
+		{fs.ErrNotExist, ClassNotFound},
+		{fs.ErrExist, ClassConflict},
+		{net.ErrClosed, ClassDown},
+		{io.ErrClosedPipe, ClassDown},
+		{http.ErrServerClosed, ClassDown},
+		{io.ErrNoProgress, ClassInternal},
+		{errors.ErrUnsupported, ClassInternal},
+	})
+}
+
+// Sentinels returns the classification table of well-known errors.
+func Sentinels() []Sentinel {
+	return slices.Clone(*sentinels.Load())
+}
+
+// RegisterSentinel assigns the class to the error, see Sentinels. Cached
+// inspections of faults are invalidated.
+//
+//	faults.RegisterSentinel(sql.ErrNoRows, faults.ClassNotFound)
+func RegisterSentinel(err error, class Class) {
+	updateSentinels(func(seq []Sentinel) []Sentinel {
+		return append(seq, Sentinel{Err: err, Class: class})
+	})
+}
+
+// DeregisterSentinel removes the error from the classification table.
+func DeregisterSentinel(err error) {
+	updateSentinels(func(seq []Sentinel) []Sentinel {
+		return slices.DeleteFunc(seq, func(s Sentinel) bool { return sameError(s.Err, err) })
+	})
+}
+
+func updateSentinels(f func([]Sentinel) []Sentinel) {
+	muSentinels.Lock()
+	defer muSentinels.Unlock()
+
+	seq := f(slices.Clone(*sentinels.Load()))
+	sentinels.Store(&seq)
+	generation.Add(1)
+}
+
+// sentinelClass returns the class of the well-known error, the error matches
+// the sentinel as errors.Is does for a single link of the chain
+// (e.g. syscall.ENOENT is fs.ErrNotExist).
+func sentinelClass(err error) Class {
+	is, _ := err.(interface{ Is(error) bool })
+
+	for _, s := range *sentinels.Load() {
+		if err == s.Err || (is != nil && is.Is(s.Err)) {
+			return s.Class
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,90 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestSentinels(t *testing.T) {
+	const errA = errors.Type("a")
+
+	_, notExist := os.Open("/.faults-do-not-exist")
+
+	for _, tt := range []struct {
+		err   error
+		class errors.Class
+	}{
+		{errA.With(context.DeadlineExceeded), errors.ClassTimeout},
+		{errA.With(os.ErrDeadlineExceeded), errors.ClassTimeout},
+		{errA.With(notExist), errors.ClassNotFound},
+		{errA.With(fmt.Errorf("x: %w", net.ErrClosed)), errors.ClassDown},
+		{errA.With(io.ErrClosedPipe), errors.ClassDown},
+		{errA.With(http.ErrServerClosed), errors.ClassDown},
+		{errA.With(errors.ErrConflict(notExist)), errors.ClassConflict},
+		{errA.With(io.EOF), ""},
+	} {
+		if class := errors.ClassOf(tt.err); class != tt.class {
+			t.Errorf("failed: %v is %q", tt.err, class)
+		}
+	}
+}
+
+func TestRegisterSentinel(t *testing.T) {
+	const errA = errors.Type("a")
+
+	n := len(errors.Sentinels())
+
+	e := errA.With(io.ErrUnexpectedEOF)
+	if class := errors.ClassOf(e); class != "" {
+		t.Errorf("failed: %v is %q", e, class)
+	}
+
+	errors.RegisterSentinel(io.ErrUnexpectedEOF, errors.ClassDegraded)
+
+	if class := errors.ClassOf(e); class != errors.ClassDegraded || len(errors.Sentinels()) != n+1 {
+		t.Errorf("failed: cached class %q", class)
+	}
+
+	errors.DeregisterSentinel(io.ErrUnexpectedEOF)
+
+	if class := errors.ClassOf(e); class != "" || len(errors.Sentinels()) != n {
+		t.Errorf("failed: class %q of deregistered sentinel", class)
+	}
+}
+
+func TestRegisterSentinelConcurrent(t *testing.T) {
+	const errA = errors.Type("a")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errors.RegisterSentinel(io.ErrShortWrite, errors.ClassDegraded)
+			errors.ClassOf(errA.With(io.ErrShortWrite))
+		}()
+	}
+	wg.Wait()
+
+	errors.DeregisterSentinel(io.ErrShortWrite)
+
+	if class := errors.ClassOf(errA.With(io.ErrShortWrite)); class != "" {
+		t.Errorf("failed: class %q of deregistered sentinel", class)
+	}
+}
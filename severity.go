@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// SeverityLevel of the fault, alerting pipelines route faults by it
+type SeverityLevel string
+
+const (
+	SeverityDebug    = SeverityLevel("debug")
+	SeverityInfo     = SeverityLevel("info")
+	SeverityWarn     = SeverityLevel("warn")
+	SeverityError    = SeverityLevel("error")
+	SeverityCritical = SeverityLevel("critical")
+)
+
+// Level maps the severity to the slog level, critical faults are logged
+// above slog.LevelError.
+//
+//	slog.Log(ctx, faults.SeverityOf(err).Level(), "request failed", "err", err)
+func (l SeverityLevel) Level() slog.Level {
+	switch l {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarn:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError
+	}
+}
+
+// SeverityOf returns the severity of the first error in the chain exposing it
+// via `Severity() SeverityLevel`, the severity of the class mapping is used
+// otherwise (see MappingOf), errors are SeverityError by default.
+// It returns empty string if the error is nil.
+//
+//	if faults.SeverityOf(err) == faults.SeverityCritical {
+//		pager.Alert(err)
+//	}
+func SeverityOf(err error) SeverityLevel {
+	if err == nil {
+		return ""
+	}
+
+	var level SeverityLevel
+	walk(err, func(err error) bool {
+		if e, ok := err.(interface{ Severity() SeverityLevel }); ok && e.Severity() != "" {
+			level = e.Severity()
+			return false
+		}
+		return true
+	})
+
+	if level == "" {
+		level = MappingOf(err).Severity
+	}
+
+	if level == "" {
+		level = SeverityError
+	}
+
+	return level
+}
+
+// Critical creates a context for the error of critical severity,
+// see Type and SeverityOf.
+//
+//	const errSome = faults.Critical("db corrupted")
+type Critical string
+
+// With wraps error into the context, see Type.With
+func (e Critical) With(err error, args ...any) error {
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// Maybe wraps error into the context if the error is not nil, see Type.Maybe
+func (e Critical) Maybe(err error, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// WithAll wraps multiple errors into the context, see Type.WithAll
+func (e Critical) WithAll(errs ...error) error {
+	return withCaller(0, newErrType(e, joinErrs(errs), nil))
+}
+
+// WrapOnce wraps error into the context once, see Type.WrapOnce
+func (e Critical) WrapOnce(err error, args ...any) error {
+	if carries(err, e) {
+		return err
+	}
+
+	return withCaller(0, newErrType(e, err, args))
+}
+
+// Must panics with the error wrapped into the context, see Type.Must
+func (e Critical) Must(err error, args ...any) {
+	if err == nil {
+		return
+	}
+
+	panic(withCaller(0, newErrType(e, err, args)))
+}
+
+// Check returns true if the error is wrapped with the context, see Type.Check
+func (e Critical) Check(err error) bool { return errors.Is(err, e) }
+
+// Severity of the context
+func (e Critical) Severity() SeverityLevel { return SeverityCritical }
+
+func (e Critical) Error() string { return string(e) }
+func (e Critical) arity() int    { return -1 }
+func (e Critical) zero() string  { return string(e) }
+
+// MatchAlso declares foreign errors matched by the context, see Type.MatchAlso
+func (e Critical) MatchAlso(errs ...error) Critical {
+	matchAlso(e, errs)
+	return e
+}
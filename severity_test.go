@@ -0,0 +1,67 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestSeverityOf(t *testing.T) {
+	const (
+		errA = errors.Type("a")
+		errB = errors.Critical("b")
+	)
+
+	for _, tt := range []struct {
+		err   error
+		level errors.SeverityLevel
+	}{
+		{nil, ""},
+		{err, errors.SeverityError},
+		{errA.With(err), errors.SeverityError},
+		{errB.With(err), errors.SeverityCritical},
+		{errA.With(errB.With(err)), errors.SeverityCritical},
+		{errA.With(errors.ErrSeverity(err, errors.SeverityWarn)), errors.SeverityWarn},
+		{errors.ErrSeverity(errB.With(err), errors.SeverityDebug), errors.SeverityDebug},
+		{errA.With(errors.ErrNotFound(err, "k")), errors.SeverityInfo},
+		{errA.With(errors.ErrDegraded(err)), errors.SeverityWarn},
+		{errB.With(errors.ErrNotFound(err, "k")), errors.SeverityCritical},
+	} {
+		if level := errors.SeverityOf(tt.err); level != tt.level {
+			t.Errorf("failed: %v is %q", tt.err, level)
+		}
+	}
+}
+
+func TestCritical(t *testing.T) {
+	const errB = errors.Critical("b %d")
+
+	e := errB.With(err, 1)
+	if !errB.Check(e) || !stderrors.Is(e, err) || errB.Maybe(nil, 1) != nil {
+		t.Errorf("failed: %v", e)
+	}
+
+	if name, _ := e.(errors.Fault).Caller(); withCallers && name != "github.com/fogfish/faults_test.TestCritical" {
+		t.Errorf("failed: caller %s", name)
+	}
+
+	if e := errB.WithAll(err); !stderrors.Is(e, err) || errors.SeverityOf(e) != errors.SeverityCritical {
+		t.Errorf("failed: %v", e)
+	}
+
+	defer func() {
+		if x := recover(); !stderrors.Is(x.(error), errB) {
+			t.Errorf("failed: %v", x)
+		}
+	}()
+	errB.Must(err, 1)
+}
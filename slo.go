@@ -0,0 +1,138 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "sync"
+
+// UserCaused returns true if the class stands for the error caused by
+// the user, the class is mapped to 4xx HTTP status (see MappingOf).
+// The rest are caused by the system.
+func UserCaused(class Class) bool {
+	status := mappingOf(class).HTTP
+	return status >= 400 && status < 500
+}
+
+// Accounting classifies the stream of outcomes into SLO buckets, so that
+// availability SLOs exclude user-caused faults automatically. The zero
+// value is ready to use. Expected faults are not failures, see Expectation.
+// Successful outcomes close to the deadline are not failures either, see
+// Type.WithElapsed.
+//
+//	var slo faults.Accounting
+//
+//	slo.Add(err)
+//	slo.Report().Availability()
+type Accounting struct {
+	mu       sync.Mutex
+	total    int
+	expected int
+	slow     int
+	classes  map[Class]int
+}
+
+// SLOReport is the snapshot of counters accumulated by Accounting
+type SLOReport struct {
+	// Total number of outcomes, including successful ones
+	Total int
+
+	// User is the number of user-caused faults
+	User int
+
+	// System is the number of system-caused faults
+	System int
+
+	// Expected is the number of expected faults, they are neither user
+	// nor system caused, see IsExpected.
+	Expected int
+
+	// Slow is the number of successful outcomes close to the deadline,
+	// they are accounted as success, see IsNearTimeout.
+	Slow int
+
+	// Classes is the number of faults per class. Unclassified errors are
+	// accounted as ClassInternal.
+	Classes map[Class]int
+}
+
+// Availability is the ratio of outcomes not failed by the system
+func (r SLOReport) Availability() float64 {
+	if r.Total == 0 {
+		return 1
+	}
+
+	return float64(r.Total-r.System) / float64(r.Total)
+}
+
+// Add the outcome to the accounting, nil error stands for success.
+func (a *Accounting) Add(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.total++
+	if err == nil {
+		return
+	}
+
+	if IsExpected(err) {
+		a.expected++
+		return
+	}
+
+	if e, ok := Extract[NearTimeoutError](err); ok && e.err == nil {
+		a.slow++
+		return
+	}
+
+	class := ClassOf(err)
+	if class == "" {
+		class = ClassInternal
+	}
+
+	if a.classes == nil {
+		a.classes = map[Class]int{}
+	}
+	a.classes[class]++
+}
+
+// Report returns the snapshot of counters.
+func (a *Accounting) Report() SLOReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.report()
+}
+
+// Reset returns the snapshot of counters and zeroes them, use it to report
+// the SLO per interval.
+func (a *Accounting) Reset() SLOReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	r := a.report()
+	a.total = 0
+	a.expected = 0
+	a.slow = 0
+	a.classes = nil
+
+	return r
+}
+
+func (a *Accounting) report() SLOReport {
+	r := SLOReport{Total: a.total, Expected: a.expected, Slow: a.slow, Classes: make(map[Class]int, len(a.classes))}
+	for class, n := range a.classes {
+		r.Classes[class] = n
+		if UserCaused(class) {
+			r.User += n
+		} else {
+			r.System += n
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,99 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestAccounting(t *testing.T) {
+	var slo errors.Accounting
+
+	if r := slo.Report(); r.Total != 0 || r.Availability() != 1 {
+		t.Errorf("failed: %+v", r)
+	}
+
+	for _, e := range []error{
+		nil,
+		nil,
+		nil,
+		nil,
+		errors.ErrNotFound(err, "k"),
+		errors.ErrConflict(err),
+		errors.ErrTimeout(err, time.Second),
+		err,
+	} {
+		slo.Add(e)
+	}
+
+	r := slo.Reset()
+	if r.Total != 8 || r.User != 2 || r.System != 2 ||
+		r.Classes[errors.ClassNotFound] != 1 ||
+		r.Classes[errors.ClassInternal] != 1 ||
+		r.Classes[errors.ClassTimeout] != 1 ||
+		r.Availability() != 0.75 {
+		t.Errorf("failed: %+v", r)
+	}
+
+	if r := slo.Report(); r.Total != 0 || len(r.Classes) != 0 {
+		t.Errorf("failed: %+v", r)
+	}
+}
+
+func TestAccountingSlow(t *testing.T) {
+	const errA = errors.Type("a")
+
+	var slo errors.Accounting
+
+	slo.Add(errA.WithElapsed(nil, 3500*time.Millisecond, 4*time.Second))
+	slo.Add(errA.WithElapsed(err, 3500*time.Millisecond, 4*time.Second))
+	slo.Add(errA.WithElapsed(nil, 5*time.Second, 4*time.Second))
+	slo.Add(errA.WithElapsed(nil, time.Millisecond, 4*time.Second))
+
+	r := slo.Reset()
+	if r.Total != 4 || r.Slow != 1 || r.System != 2 || r.Availability() != 0.5 || r.Classes[errors.ClassNearTimeout] != 1 {
+		t.Errorf("failed: %+v", r)
+	}
+
+	if r := slo.Report(); r.Slow != 0 {
+		t.Errorf("failed: %+v", r)
+	}
+}
+
+func TestUserCaused(t *testing.T) {
+	for class, expect := range map[errors.Class]bool{
+		errors.ClassNotFound: true,
+		errors.ClassGone:     true,
+		errors.ClassTimeout:  false,
+		errors.ClassInternal: false,
+		"slo_quota":          false,
+		"":                   false,
+	} {
+		if errors.UserCaused(class) != expect {
+			t.Errorf("failed: %s", class)
+		}
+	}
+
+	errors.LoadMappings(strings.NewReader(`{"slo_quota": {"http": 429}, "conflict": {"http": 500}}`))
+	defer errors.LoadMappings(strings.NewReader(`{"slo_quota": {"http": 500}, "conflict": {"http": 409}}`))
+
+	if !errors.UserCaused("slo_quota") || errors.UserCaused(errors.ClassConflict) {
+		t.Errorf("failed: mappings are not used")
+	}
+
+	var slo errors.Accounting
+	slo.Add(errors.ErrClass(err, "slo_quota"))
+	if r := slo.Report(); r.User != 1 || r.System != 0 {
+		t.Errorf("failed: %+v", r)
+	}
+}
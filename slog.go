@@ -0,0 +1,72 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer, the fault is logged as the group
+// of its type, code, caller, args, fields and the cause chain.
+//
+//	slog.Error("failed", "err", err)
+func (e *errType) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 8)
+
+	attrs = append(attrs,
+		slog.String("message", e.Message()),
+		slog.String("type", e.head.Error()),
+	)
+
+	if code := e.Code(); code != "" {
+		attrs = append(attrs, slog.String("code", code))
+	}
+
+	if name, line := e.location(); name != "" {
+		attrs = append(attrs, slog.String("caller", name+":"+strconv.Itoa(line)))
+	}
+
+	if len(e.args) > 0 {
+		attrs = append(attrs, slog.Any("args", e.args))
+	}
+
+	for _, field := range e.fields {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+
+	if e.build != nil {
+		attrs = append(attrs, slog.Group("build",
+			slog.String("version", e.build.Version),
+			slog.String("revision", e.build.Revision),
+		))
+	}
+
+	if e.tail != nil {
+		attrs = append(attrs, causeAttr(e.tail))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+func causeAttr(err error) slog.Attr {
+	switch x := err.(type) {
+	case *errType:
+		return slog.Any("cause", x)
+	case *errList:
+		attrs := make([]slog.Attr, len(x.errs))
+		for i, err := range x.errs {
+			attrs[i] = causeAttr(err)
+			attrs[i].Key = strconv.Itoa(i)
+		}
+		return slog.Attr{Key: "cause", Value: slog.GroupValue(attrs...)}
+	default:
+		return slog.String("cause", err.Error())
+	}
+}
@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestLogValue(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Fast("b")
+	)
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	log.Error("failed", "err", errA.With(errB.WithAll(err, errB.With(nil)), 1, errors.F("user", "u")))
+
+	var entry struct {
+		Err struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+			Caller  string `json:"caller"`
+			Args    []int  `json:"args"`
+			User    string `json:"user"`
+			Cause   struct {
+				Message string `json:"message"`
+				Cause   map[string]any
+			} `json:"cause"`
+		} `json:"err"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	x := entry.Err
+	if x.Message != "a 1" || x.Type != "a %d" ||
+		(withCallers && x.Caller != "github.com/fogfish/faults_test.TestLogValue:29") ||
+		len(x.Args) != 1 || x.User != "u" ||
+		x.Cause.Message != "b" ||
+		x.Cause.Cause["0"] != "just error" {
+		t.Errorf("failed: %s", buf.String())
+	}
+
+	if m, ok := x.Cause.Cause["1"].(map[string]any); !ok || m["message"] != "b" {
+		t.Errorf("failed: %s", buf.String())
+	}
+}
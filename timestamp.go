@@ -0,0 +1,33 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import "time"
+
+// TimeOf returns the creation time of the outermost timestamped fault in
+// the chain, see Timestamps.
+//
+//	faults.Configure(faults.Timestamps(true))
+//
+//	if at, ok := faults.TimeOf(err); ok {
+//		latency := time.Since(at)
+//	}
+func TimeOf(err error) (time.Time, bool) {
+	var at time.Time
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(*errType); ok && !e.at.IsZero() {
+			at = e.at
+			return false
+		}
+		return true
+	})
+
+	return at, !at.IsZero()
+}
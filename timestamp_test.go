@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"testing"
+	"time"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestTimeOf(t *testing.T) {
+	const errA = errors.Type("a")
+
+	if _, ok := errors.TimeOf(errA.With(err)); ok {
+		t.Errorf("failed: timestamps are disabled")
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	errors.Configure(
+		errors.Timestamps(true),
+		errors.Clock(func() time.Time { return now }),
+	)
+	defer errors.Configure(errors.Timestamps(false), errors.Clock(nil))
+
+	e := errA.With(err)
+
+	if at, ok := errors.TimeOf(errA.With(e)); !ok || !at.Equal(now) {
+		t.Errorf("failed: %v", at)
+	}
+
+	if _, ok := errors.TimeOf(err); ok {
+		t.Errorf("failed: foreign error")
+	}
+}
@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Attributes of the error expected by Datadog Error Tracking
+const (
+	KeyErrorKind    = "error.kind"
+	KeyErrorMessage = "error.message"
+	KeyErrorStack   = "error.stack"
+)
+
+// ErrorTracking computes the attribute trio expected by Datadog Error
+// Tracking from the fault chain:
+//
+//	error.kind     code of the outermost fault in the chain, its template
+//	               if code is not declared, the type name of foreign errors
+//	error.message  error message
+//	error.stack    call stack captured by Deep context, otherwise it is
+//	               reconstructed from callers of faults in the chain
+//
+// It returns nil if the error is nil. The map is the attribute set for any
+// logger (e.g. zap.Any, zerolog's Fields), see TrackingHandler for slog.
+//
+//	for key, val := range faults.ErrorTracking(err) {
+//		span.SetTag(key, val)
+//	}
+func ErrorTracking(err error) map[string]string {
+	if err == nil {
+		return nil
+	}
+
+	kv := map[string]string{
+		KeyErrorKind:    fmt.Sprintf("%T", err),
+		KeyErrorMessage: err.Error(),
+	}
+
+	walk(err, func(err error) bool {
+		e, ok := err.(*errType)
+		if !ok {
+			return true
+		}
+
+		kv[KeyErrorKind] = e.head.Error()
+		if code := e.Code(); code != "" {
+			kv[KeyErrorKind] = code
+		}
+		return false
+	})
+
+	if stack := errorStack(err); stack != "" {
+		kv[KeyErrorStack] = stack
+	}
+
+	return kv
+}
+
+func errorStack(err error) string {
+	var sb strings.Builder
+
+	if frames := StackOf(err); len(frames) > 0 {
+		for _, frame := range frames {
+			sb.WriteString(frame.Function)
+			sb.WriteString("\n\t")
+			sb.WriteString(frame.File)
+			sb.WriteString(":")
+			sb.WriteString(strconv.Itoa(frame.Line))
+			sb.WriteString("\n")
+		}
+		return sb.String()
+	}
+
+	walk(err, func(err error) bool {
+		if e, ok := err.(*errType); ok && e.pc != 0 {
+			name, line := e.location()
+			sb.WriteString(name)
+			sb.WriteString(":")
+			sb.WriteString(strconv.Itoa(line))
+			sb.WriteString("\n")
+		}
+		return true
+	})
+
+	return sb.String()
+}
+
+// TrackingHandler decorates the slog handler with Datadog Error Tracking
+// attributes of the first error logged with the record, see ErrorTracking.
+//
+//	log := slog.New(faults.TrackingHandler(slog.NewJSONHandler(os.Stdout, nil)))
+//	log.Error("request is failed", "err", err)
+func TrackingHandler(h slog.Handler) slog.Handler { return trackingHandler{h} }
+
+type trackingHandler struct{ slog.Handler }
+
+func (h trackingHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	r.Attrs(func(a slog.Attr) bool {
+		err, _ = a.Value.Any().(error)
+		return err == nil
+	})
+
+	if kv := ErrorTracking(err); kv != nil {
+		r.AddAttrs(slog.String(KeyErrorKind, kv[KeyErrorKind]), slog.String(KeyErrorMessage, kv[KeyErrorMessage]))
+		if stack, has := kv[KeyErrorStack]; has {
+			r.AddAttrs(slog.String(KeyErrorStack, stack))
+		}
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h trackingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return trackingHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h trackingHandler) WithGroup(name string) slog.Handler {
+	return trackingHandler{h.Handler.WithGroup(name)}
+}
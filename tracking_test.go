@@ -0,0 +1,84 @@
+//
+// Copyright (C) 2020 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/errors
+//
+
+package faults_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	errors "github.com/fogfish/faults"
+)
+
+func TestErrorTracking(t *testing.T) {
+	const (
+		errA = errors.Type("a %d")
+		errB = errors.Type("b")
+		errC = errors.Deep("c")
+	)
+
+	e := errA.With(errB.With(err), 1)
+	kv := errors.ErrorTracking(e)
+
+	if kv[errors.KeyErrorKind] != "a %d" ||
+		kv[errors.KeyErrorMessage] != e.Error() ||
+		(withCallers && kv[errors.KeyErrorStack] != "github.com/fogfish/faults_test.TestErrorTracking:29\ngithub.com/fogfish/faults_test.TestErrorTracking:29\n") {
+		t.Errorf("failed: %v", kv)
+	}
+
+	kv = errors.ErrorTracking(errA.With(errC.With(err), 1))
+	if withCallers && !strings.HasPrefix(kv[errors.KeyErrorStack], "github.com/fogfish/faults_test.TestErrorTracking\n\t") {
+		t.Errorf("failed: %v", kv)
+	}
+
+	kv = errors.ErrorTracking(err)
+	if len(kv) != 2 || kv[errors.KeyErrorKind] != "*errors.errorString" {
+		t.Errorf("failed: %v", kv)
+	}
+
+	kv = errors.ErrorTracking(fmt.Errorf("x: %w", errors.ErrGone(errors.Code("E1", "d").With(err))))
+	if kv[errors.KeyErrorKind] != "E1" {
+		t.Errorf("failed: kind of wrapped fault %v", kv)
+	}
+
+	if errors.ErrorTracking(nil) != nil {
+		t.Errorf("failed: nil")
+	}
+}
+
+func TestTrackingHandler(t *testing.T) {
+	errA := errors.Code("E1", "a")
+
+	var buf bytes.Buffer
+	log := slog.New(errors.TrackingHandler(slog.NewJSONHandler(&buf, nil))).With("service", "s")
+
+	log.Error("failed", "user", "u", "err", errA.With(err))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+
+	if entry[errors.KeyErrorKind] != "E1" || entry[errors.KeyErrorMessage] == nil || entry["service"] != "s" {
+		t.Errorf("failed: %s", buf.String())
+	}
+
+	if _, has := entry[errors.KeyErrorStack]; has != withCallers {
+		t.Errorf("failed: stack %s", buf.String())
+	}
+
+	buf.Reset()
+	log.Info("done")
+	if strings.Contains(buf.String(), errors.KeyErrorKind) {
+		t.Errorf("failed: %s", buf.String())
+	}
+}